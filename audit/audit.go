@@ -0,0 +1,232 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/naveenthangaraj03/k8s-mcp-server/auth"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+var auditLog string
+var redactKeys string
+
+func init() {
+	flag.StringVar(&auditLog, "auditLog", "", "Write an audit record of every tool call as JSON lines to this file, or to \"stderr\", or \"stdout\" with the http and sse transports. Disabled when empty")
+	flag.StringVar(&redactKeys, "auditRedact", "data,token,password,secret", "Comma separated argument names whose value is redacted in the audit log, matched case-insensitively as substrings")
+}
+
+const redacted = "[REDACTED]"
+
+// Record is one audit log line.
+type Record struct {
+	Timestamp  time.Time              `json:"timestamp"`
+	Tool       string                 `json:"tool"`
+	Arguments  map[string]interface{} `json:"arguments,omitempty"`
+	Cluster    string                 `json:"cluster,omitempty"`
+	User       string                 `json:"user,omitempty"`
+	Result     string                 `json:"result"`
+	DurationMs int64                  `json:"durationMs"`
+	Error      string                 `json:"error,omitempty"`
+}
+
+// Logger writes audit records to a sink. It is safe for concurrent use.
+type Logger struct {
+	mu     sync.Mutex
+	out    io.Writer
+	closer io.Closer
+	redact []string
+}
+
+// Open returns the logger configured by --auditLog, or nil when auditing is
+// disabled. stdout is refused when stdio is set, since the stdio transport
+// sends the MCP messages on it.
+func Open(stdio bool) (*Logger, error) {
+	if auditLog == "" {
+		return nil, nil
+	}
+	logger := &Logger{}
+	for _, key := range strings.Split(redactKeys, ",") {
+		if key = strings.ToLower(strings.TrimSpace(key)); key != "" {
+			logger.redact = append(logger.redact, key)
+		}
+	}
+	switch auditLog {
+	case "stdout":
+		if stdio {
+			return nil, fmt.Errorf("--auditLog=stdout cannot be used with the stdio transport, it writes the MCP messages to stdout")
+		}
+		logger.out = os.Stdout
+	case "stderr":
+		logger.out = os.Stderr
+	default:
+		file, err := os.OpenFile(auditLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("opening audit log %s: %w", auditLog, err)
+		}
+		logger.out = file
+		logger.closer = file
+	}
+	return logger, nil
+}
+
+// Close closes the audit log file.
+func (l *Logger) Close() error {
+	if l == nil || l.closer == nil {
+		return nil
+	}
+	return l.closer.Close()
+}
+
+// Middleware records every tool call with its redacted arguments, target
+// cluster, authenticated caller, outcome and duration.
+func (l *Logger) Middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start := time.Now()
+		result, err := next(ctx, request)
+
+		record := Record{
+			Timestamp:  start.UTC(),
+			Tool:       request.Params.Name,
+			Arguments:  l.redactArguments(request.GetArguments()),
+			Result:     "success",
+			DurationMs: time.Since(start).Milliseconds(),
+		}
+		if cluster, clusterErr := client.ContextName(request); clusterErr == nil {
+			record.Cluster = cluster
+		}
+		if user := auth.UserFrom(ctx); user != nil {
+			record.User = user.Name
+		}
+		switch {
+		case err != nil:
+			record.Result = "error"
+			record.Error = err.Error()
+		case result != nil && result.IsError:
+			record.Result = "error"
+			record.Error = resultText(result)
+		}
+		l.write(record)
+		return result, err
+	}
+}
+
+func (l *Logger) write(record Record) {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.out.Write(append(line, '\n'))
+}
+
+// redactArguments redacts the arguments whose name matches --auditRedact,
+// in nested objects too, the Secret payloads of an apply-manifest manifest and the patch of a
+// patch-resource call on secrets.
+func (l *Logger) redactArguments(arguments map[string]any) map[string]interface{} {
+	if len(arguments) == 0 {
+		return nil
+	}
+	output := l.redactObject(arguments)
+	if manifest, ok := output["manifest"].(string); ok {
+		output["manifest"] = redactManifest(manifest)
+	}
+	if resource, ok := output["resource"].(string); ok && strings.Contains(strings.ToLower(resource), "secret") {
+		if _, ok := output["patch"]; ok {
+			output["patch"] = redacted
+		}
+	}
+	return output
+}
+
+// redactObject returns a copy of object with the values whose key matches
+// --auditRedact redacted, at any depth.
+func (l *Logger) redactObject(object map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{}, len(object))
+	for key, value := range object {
+		output[key] = l.redactValue(value)
+		lower := strings.ToLower(key)
+		for _, redact := range l.redact {
+			if strings.Contains(lower, redact) {
+				output[key] = redacted
+				break
+			}
+		}
+	}
+	return output
+}
+
+func (l *Logger) redactValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		return l.redactObject(value)
+	case []interface{}:
+		output := make([]interface{}, len(value))
+		for i, item := range value {
+			output[i] = l.redactValue(item)
+		}
+		return output
+	}
+	return value
+}
+
+// redactManifest returns the documents of manifest with the data and
+// stringData of every Secret redacted, including the items of Lists. A
+// manifest that cannot be parsed is redacted as a whole.
+func redactManifest(manifest string) interface{} {
+	decoder := yaml.NewYAMLOrJSONDecoder(strings.NewReader(manifest), 4096)
+	documents := []interface{}{}
+	for {
+		var document map[string]interface{}
+		if err := decoder.Decode(&document); err != nil {
+			if err == io.EOF {
+				return documents
+			}
+			return redacted
+		}
+		if len(document) == 0 {
+			continue
+		}
+		redactSecret(document)
+		documents = append(documents, document)
+	}
+}
+
+func redactSecret(object map[string]interface{}) {
+	if items, ok := object["items"].([]interface{}); ok {
+		for _, item := range items {
+			if item, ok := item.(map[string]interface{}); ok {
+				redactSecret(item)
+			}
+		}
+	}
+	if kind, _ := object["kind"].(string); kind != "Secret" {
+		return
+	}
+	for _, field := range []string{"data", "stringData"} {
+		if _, ok := object[field]; ok {
+			object[field] = redacted
+		}
+	}
+}
+
+func resultText(result *mcp.CallToolResult) string {
+	var texts []string
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
@@ -0,0 +1,131 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// record runs a call with arguments through the audit middleware and returns
+// the line it wrote.
+func record(t *testing.T, tool string, arguments map[string]any) string {
+	t.Helper()
+	var out bytes.Buffer
+	logger := &Logger{out: &out, redact: []string{"data", "token", "password", "secret"}}
+	handler := logger.Middleware(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("done"), nil
+	})
+	request := mcp.CallToolRequest{}
+	request.Params.Name = tool
+	request.Params.Arguments = arguments
+	if _, err := handler(context.Background(), request); err != nil {
+		t.Fatal(err)
+	}
+	return out.String()
+}
+
+func TestRedactArguments(t *testing.T) {
+	tests := []struct {
+		arguments map[string]any
+		hidden    []string
+		kept      []string
+	}{
+		{map[string]any{"name": "app", "token": "t0ken"}, []string{"t0ken"}, []string{"app"}},
+		{map[string]any{"Password": "hunter2", "namespace": "default"}, []string{"hunter2"}, []string{"default"}},
+		{map[string]any{"bearerTokenValue": "abc123"}, []string{"abc123"}, nil},
+		{map[string]any{"data": map[string]any{"user": "admin", "key": "s3cr3t"}}, []string{"admin", "s3cr3t"}, nil},
+		{map[string]any{"env": map[string]any{"DB_PASSWORD": "pa55", "LOG_LEVEL": "debug"}}, []string{"pa55"}, []string{"debug"}},
+		{map[string]any{"containers": []any{map[string]any{"name": "web", "apiToken": "xyz789"}}}, []string{"xyz789"}, []string{"web"}},
+		{map[string]any{"secretName": "db-credentials"}, []string{"db-credentials"}, nil},
+	}
+	for _, test := range tests {
+		line := record(t, "create-resource", test.arguments)
+		for _, value := range test.hidden {
+			if strings.Contains(line, value) {
+				t.Errorf("audit line for %v holds %q: %s", test.arguments, value, line)
+			}
+		}
+		for _, value := range test.kept {
+			if !strings.Contains(line, value) {
+				t.Errorf("audit line for %v lost %q: %s", test.arguments, value, line)
+			}
+		}
+		if !strings.Contains(line, redacted) {
+			t.Errorf("audit line for %v has no redaction marker: %s", test.arguments, line)
+		}
+	}
+}
+
+func TestRedactManifestAndPatch(t *testing.T) {
+	tests := []struct {
+		tool      string
+		arguments map[string]any
+		hidden    []string
+		kept      []string
+	}{
+		{"apply-manifest", map[string]any{"manifest": `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: db
+data:
+  password: aHVudGVyMg==
+stringData:
+  user: admin
+`}, []string{"aHVudGVyMg==", "admin"}, []string{"db"}},
+		{"apply-manifest", map[string]any{"manifest": `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+data:
+  level: debug
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: tls
+stringData:
+  tls.key: private-key
+`}, []string{"private-key"}, []string{"settings", "debug", "tls"}},
+		{"apply-manifest", map[string]any{"manifest": `{"apiVersion":"v1","kind":"Secret","metadata":{"name":"api"},"stringData":{"key":"j50n"}}`}, []string{"j50n"}, []string{"api"}},
+		{"apply-manifest", map[string]any{"manifest": `
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Secret
+  metadata:
+    name: listed
+  data:
+    token: bGlzdGVk
+`}, []string{"bGlzdGVk"}, []string{"listed"}},
+		// A manifest that does not parse is redacted as a whole.
+		{"apply-manifest", map[string]any{"manifest": "kind: Secret\nstringData: {key: unparsed\n"}, []string{"unparsed"}, nil},
+		{"patch-resource", map[string]any{"resource": "secrets", "name": "db", "patch": `{"stringData":{"password":"patched"}}`}, []string{"patched"}, []string{"db"}},
+		{"patch-resource", map[string]any{"resource": "Secret", "name": "db", "patch": `{"data":{"password":"cGF0Y2hlZA=="}}`}, []string{"cGF0Y2hlZA=="}, nil},
+		{"patch-resource", map[string]any{"resource": "sealedsecrets.bitnami.com", "name": "db", "patch": `{"spec":{"encryptedData":{"key":"AgBy3i4O"}}}`}, []string{"AgBy3i4O"}, nil},
+	}
+	for _, test := range tests {
+		line := record(t, test.tool, test.arguments)
+		for _, value := range test.hidden {
+			if strings.Contains(line, value) {
+				t.Errorf("audit line for %s %v holds %q: %s", test.tool, test.arguments, value, line)
+			}
+		}
+		for _, value := range test.kept {
+			if !strings.Contains(line, value) {
+				t.Errorf("audit line for %s %v lost %q: %s", test.tool, test.arguments, value, line)
+			}
+		}
+	}
+
+	// The patch of other resources is kept.
+	line := record(t, "patch-resource", map[string]any{"resource": "deployments", "name": "web", "patch": `{"spec":{"replicas":3}}`})
+	if !strings.Contains(line, "replicas") {
+		t.Errorf("audit line for a deployment patch lost the patch: %s", line)
+	}
+}
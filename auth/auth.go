@@ -0,0 +1,220 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+)
+
+var tokenFile string
+var oidcIssuer string
+var oidcClientID string
+var oidcUsernameClaim string
+var oidcGroupsClaim string
+var oidcUsernamePrefix string
+var oidcGroupsPrefix string
+
+func init() {
+	flag.StringVar(&tokenFile, "authTokenFile", "", "Static bearer tokens accepted by the http and sse transports, one token,user,uid,\"group1,group2\" line per caller like the Kubernetes static token file")
+	flag.StringVar(&oidcIssuer, "oidcIssuer", "", "OIDC issuer URL whose ID tokens are accepted as bearer tokens by the http and sse transports")
+	flag.StringVar(&oidcClientID, "oidcClientID", "", "Client ID the OIDC ID tokens must be issued for")
+	flag.StringVar(&oidcUsernameClaim, "oidcUsernameClaim", "sub", "OIDC claim used as the Kubernetes user name")
+	flag.StringVar(&oidcGroupsClaim, "oidcGroupsClaim", "groups", "OIDC claim used as the Kubernetes groups")
+	flag.StringVar(&oidcUsernamePrefix, "oidcUsernamePrefix", "oidc:", "Prefix added to the OIDC user name")
+	flag.StringVar(&oidcGroupsPrefix, "oidcGroupsPrefix", "oidc:", "Prefix added to every OIDC group")
+}
+
+// User is the authenticated caller of an HTTP request. Tool calls made by a
+// User run against the cluster impersonating its name and groups.
+type User struct {
+	Name   string
+	Groups []string
+}
+
+// Key identifies the user and its groups, two calls with the same Key act
+// with the same permissions.
+func (u *User) Key() string {
+	return u.Name + "\x00" + strings.Join(u.Groups, "\x00")
+}
+
+type userKey struct{}
+
+// WithUser returns a copy of ctx carrying user.
+func WithUser(ctx context.Context, user *User) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// UserFrom returns the caller stored in ctx, nil when the call was not
+// authenticated, like every call over stdio.
+func UserFrom(ctx context.Context) *User {
+	user, _ := ctx.Value(userKey{}).(*User)
+	return user
+}
+
+// Enabled reports whether any authentication method is configured.
+func Enabled() bool {
+	return tokenFile != "" || oidcIssuer != ""
+}
+
+// Authenticator checks the bearer token of HTTP requests against the static
+// tokens and the OIDC issuer.
+type Authenticator struct {
+	tokens   map[[sha256.Size]byte]*User
+	verifier *oidc.IDTokenVerifier
+}
+
+// New returns the authenticator configured by the flags, or nil when
+// authentication is disabled. The OIDC discovery document is fetched once at
+// startup, signing keys are fetched and rotated as tokens need them.
+func New(ctx context.Context) (*Authenticator, error) {
+	if !Enabled() {
+		return nil, nil
+	}
+	a := &Authenticator{}
+	if tokenFile != "" {
+		tokens, err := loadTokens(tokenFile)
+		if err != nil {
+			return nil, err
+		}
+		a.tokens = tokens
+	}
+	if oidcIssuer != "" {
+		if oidcClientID == "" {
+			return nil, fmt.Errorf("--oidcClientID is required with --oidcIssuer")
+		}
+		provider, err := oidc.NewProvider(ctx, oidcIssuer)
+		if err != nil {
+			return nil, fmt.Errorf("discovering OIDC issuer %s: %w", oidcIssuer, err)
+		}
+		a.verifier = provider.Verifier(&oidc.Config{ClientID: oidcClientID})
+	}
+	return a, nil
+}
+
+// Middleware rejects requests without a valid bearer token with 401 and
+// passes the others on with the caller stored in the request context.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, err := a.authenticate(r)
+		if err != nil {
+			log.Printf("auth: rejected request from %s: %v", r.RemoteAddr, err)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="k8s-mcp-server"`)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(WithUser(r.Context(), user)))
+	})
+}
+
+func (a *Authenticator) authenticate(r *http.Request) (*User, error) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return nil, errors.New("no bearer token")
+	}
+	token = strings.TrimSpace(token)
+	if user, ok := a.tokens[sha256.Sum256([]byte(token))]; ok {
+		return user, nil
+	}
+	if a.verifier == nil {
+		return nil, errors.New("unknown token")
+	}
+	idToken, err := a.verifier.Verify(r.Context(), token)
+	if err != nil {
+		return nil, err
+	}
+	var claims map[string]interface{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, err
+	}
+	return oidcUser(claims)
+}
+
+// oidcUser maps the claims of an ID token to a user. Names and groups in the
+// reserved system: namespace are refused so a token cannot impersonate
+// Kubernetes components.
+func oidcUser(claims map[string]interface{}) (*User, error) {
+	name, _ := claims[oidcUsernameClaim].(string)
+	if name == "" {
+		return nil, fmt.Errorf("token has no %s claim", oidcUsernameClaim)
+	}
+	if oidcUsernameClaim == "email" {
+		if verified, ok := claims["email_verified"].(bool); ok && !verified {
+			return nil, fmt.Errorf("email %s is not verified", name)
+		}
+	}
+	user := &User{Name: oidcUsernamePrefix + name}
+	if strings.HasPrefix(user.Name, "system:") {
+		return nil, fmt.Errorf("user %s is reserved", user.Name)
+	}
+	var groups []string
+	switch value := claims[oidcGroupsClaim].(type) {
+	case string:
+		groups = []string{value}
+	case []interface{}:
+		for _, group := range value {
+			if group, ok := group.(string); ok {
+				groups = append(groups, group)
+			}
+		}
+	}
+	for _, group := range groups {
+		if group = oidcGroupsPrefix + group; !strings.HasPrefix(group, "system:") {
+			user.Groups = append(user.Groups, group)
+		}
+	}
+	sort.Strings(user.Groups)
+	return user, nil
+}
+
+// loadTokens reads a static token file. Tokens are kept hashed so the
+// lookup does not compare secrets byte by byte.
+func loadTokens(path string) (map[[sha256.Size]byte]*User, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening token file: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	tokens := map[[sha256.Size]byte]*User{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading token file %s: %w", path, err)
+		}
+		line, _ := reader.FieldPos(0)
+		if len(record) < 2 || record[0] == "" || record[1] == "" {
+			return nil, fmt.Errorf("token file %s line %d: expected token,user[,uid[,groups]]", path, line)
+		}
+		user := &User{Name: record[1]}
+		if len(record) > 3 {
+			for _, group := range strings.Split(record[3], ",") {
+				if group = strings.TrimSpace(group); group != "" {
+					user.Groups = append(user.Groups, group)
+				}
+			}
+		}
+		sort.Strings(user.Groups)
+		tokens[sha256.Sum256([]byte(record[0]))] = user
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("token file %s has no token", path)
+	}
+	return tokens, nil
+}
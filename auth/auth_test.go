@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadTokens(t *testing.T) {
+	tests := []struct {
+		file    string
+		want    map[string]User
+		wantErr bool
+	}{
+		{"t1,alice\n", map[string]User{"t1": {Name: "alice"}}, false},
+		{"t1,alice,uid1\nt2,bob,uid2,\"ops, dev\"\n", map[string]User{
+			"t1": {Name: "alice"},
+			"t2": {Name: "bob", Groups: []string{"dev", "ops"}},
+		}, false},
+		{"t3, carol,,\"b,,a\"\n", map[string]User{"t3": {Name: "carol", Groups: []string{"a", "b"}}}, false},
+		{"", nil, true},
+		{"t1\n", nil, true},
+		{",alice\n", nil, true},
+		{"t1,\n", nil, true},
+		{"t1,alice\nt2,\"bob\n", nil, true},
+	}
+	for _, test := range tests {
+		path := filepath.Join(t.TempDir(), "tokens.csv")
+		if err := os.WriteFile(path, []byte(test.file), 0600); err != nil {
+			t.Fatal(err)
+		}
+		tokens, err := loadTokens(path)
+		if (err != nil) != test.wantErr {
+			t.Errorf("loadTokens(%q) error = %v, want error %v", test.file, err, test.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		got := map[[sha256.Size]byte]User{}
+		for hash, user := range tokens {
+			got[hash] = *user
+		}
+		want := map[[sha256.Size]byte]User{}
+		for token, user := range test.want {
+			want[sha256.Sum256([]byte(token))] = user
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("loadTokens(%q) = %v, want %v", test.file, got, want)
+		}
+	}
+	if _, err := loadTokens(filepath.Join(t.TempDir(), "missing.csv")); err == nil {
+		t.Errorf("loadTokens of a missing file succeeded")
+	}
+}
+
+func TestOIDCUser(t *testing.T) {
+	defer func(usernameClaim, groupsClaim, usernamePrefix, groupsPrefix string) {
+		oidcUsernameClaim, oidcGroupsClaim, oidcUsernamePrefix, oidcGroupsPrefix = usernameClaim, groupsClaim, usernamePrefix, groupsPrefix
+	}(oidcUsernameClaim, oidcGroupsClaim, oidcUsernamePrefix, oidcGroupsPrefix)
+	oidcGroupsClaim = "groups"
+	tests := []struct {
+		usernameClaim, usernamePrefix, groupsPrefix string
+		claims                                      map[string]interface{}
+		want                                        *User
+	}{
+		{"sub", "oidc:", "oidc:", map[string]interface{}{"sub": "alice"}, &User{Name: "oidc:alice"}},
+		{"sub", "", "", map[string]interface{}{"sub": "alice", "groups": "ops"}, &User{Name: "alice", Groups: []string{"ops"}}},
+		{"sub", "oidc:", "oidc:", map[string]interface{}{"sub": "alice", "groups": []interface{}{"ops", "dev", 7}}, &User{Name: "oidc:alice", Groups: []string{"oidc:dev", "oidc:ops"}}},
+		{"sub", "", "", map[string]interface{}{"sub": "alice"}, &User{Name: "alice"}},
+		{"sub", "", "", map[string]interface{}{"name": "alice"}, nil},
+		{"sub", "", "", map[string]interface{}{"sub": 42}, nil},
+		{"email", "", "", map[string]interface{}{"email": "a@example.com", "email_verified": true}, &User{Name: "a@example.com"}},
+		{"email", "", "", map[string]interface{}{"email": "a@example.com"}, &User{Name: "a@example.com"}},
+		{"email", "", "", map[string]interface{}{"email": "a@example.com", "email_verified": false}, nil},
+		// Names and groups in the system: namespace are refused.
+		{"sub", "", "", map[string]interface{}{"sub": "system:kube-controller-manager"}, nil},
+		{"sub", "system:", "", map[string]interface{}{"sub": "alice"}, nil},
+		{"sub", "oidc:", "oidc:", map[string]interface{}{"sub": "system:admin"}, &User{Name: "oidc:system:admin"}},
+		{"sub", "", "", map[string]interface{}{"sub": "alice", "groups": []interface{}{"system:masters", "dev"}}, &User{Name: "alice", Groups: []string{"dev"}}},
+		{"sub", "", "system:", map[string]interface{}{"sub": "alice", "groups": []interface{}{"dev"}}, &User{Name: "alice"}},
+		{"sub", "", "oidc:", map[string]interface{}{"sub": "alice", "groups": []interface{}{"system:masters"}}, &User{Name: "alice", Groups: []string{"oidc:system:masters"}}},
+	}
+	for _, test := range tests {
+		oidcUsernameClaim, oidcUsernamePrefix, oidcGroupsPrefix = test.usernameClaim, test.usernamePrefix, test.groupsPrefix
+		got, err := oidcUser(test.claims)
+		if test.want == nil {
+			if err == nil {
+				t.Errorf("oidcUser(%v) with claim %s and prefixes %q, %q = %+v, want an error", test.claims, test.usernameClaim, test.usernamePrefix, test.groupsPrefix, got)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, test.want) {
+			t.Errorf("oidcUser(%v) with claim %s and prefixes %q, %q = %+v, %v, want %+v", test.claims, test.usernameClaim, test.usernamePrefix, test.groupsPrefix, got, err, test.want)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package apiresource
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+)
+
+type apiResourceData struct {
+	Name       string   `json:"name,omitempty"`
+	ShortNames []string `json:"shortNames,omitempty"`
+	APIVersion string   `json:"apiVersion,omitempty"`
+	Kind       string   `json:"kind,omitempty"`
+	Namespaced bool     `json:"namespaced"`
+	Verbs      []string `json:"verbs,omitempty"`
+}
+
+type apiResourcesData struct {
+	Resources    []apiResourceData `json:"resources"`
+	FailedGroups map[string]string `json:"failedGroups,omitempty"`
+}
+
+type explainData struct {
+	Kind        string      `json:"kind,omitempty"`
+	APIVersion  string      `json:"apiVersion,omitempty"`
+	Field       string      `json:"field,omitempty"`
+	Type        string      `json:"type,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Fields      []fieldData `json:"fields,omitempty"`
+}
+
+type fieldData struct {
+	Name        string `json:"name"`
+	Type        string `json:"type,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Output schemas of the api-resources and explain tools.
+var (
+	ListOutput    = mcp.WithOutputSchema[apiResourcesData]()
+	ExplainOutput = mcp.WithOutputSchema[explainData]()
+)
+
+func ListAPIResources(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	apiGroup, filterGroup := request.GetArguments()["apiGroup"].(string)
+	if apiGroup == "core" {
+		apiGroup = ""
+	}
+	cluster, err := client.GetCluster(ctx, request)
+	if err != nil {
+		return result.Error(err, "Error in intialize client")
+	}
+	// Resetting the mapper invalidates the cached discovery too, so the
+	// resources of CRDs installed since the last call are listed and mapped.
+	cluster.Mapper.Reset()
+	_, lists, err := cluster.Discovery.ServerGroupsAndResources()
+	output := apiResourcesData{Resources: []apiResourceData{}}
+	if err != nil {
+		failed, ok := err.(*discovery.ErrGroupDiscoveryFailed)
+		if !ok {
+			return result.Error(err, "Error in discovering api resources")
+		}
+		output.FailedGroups = map[string]string{}
+		for gv, groupErr := range failed.Groups {
+			output.FailedGroups[gv.String()] = groupErr.Error()
+		}
+	}
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		if filterGroup && gv.Group != apiGroup {
+			continue
+		}
+		for _, resource := range list.APIResources {
+			if strings.Contains(resource.Name, "/") {
+				continue
+			}
+			output.Resources = append(output.Resources, apiResourceData{
+				Name:       resource.Name,
+				ShortNames: resource.ShortNames,
+				APIVersion: list.GroupVersion,
+				Kind:       resource.Kind,
+				Namespaced: resource.Namespaced,
+				Verbs:      resource.Verbs,
+			})
+		}
+	}
+	sort.Slice(output.Resources, func(i, j int) bool {
+		if output.Resources[i].APIVersion != output.Resources[j].APIVersion {
+			return output.Resources[i].APIVersion < output.Resources[j].APIVersion
+		}
+		return output.Resources[i].Name < output.Resources[j].Name
+	})
+	return result.JSON(output)
+}
+
+func Explain(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	field, err := request.RequireString("field")
+	if err != nil {
+		output := fmt.Sprintf("Provide the resource or field path to explain like deployment.spec.strategy")
+		return result.Invalid(output)
+	}
+	cluster, err := client.GetCluster(ctx, request)
+	if err != nil {
+		return result.Error(err, "Error in intialize client")
+	}
+	path := strings.Split(field, ".")
+	mapping, err := cluster.RESTMapping(path[0], request.GetString("apiVersion", ""))
+	if err != nil {
+		return result.Error(err, "Error in resolving resource %s", path[0])
+	}
+	gvk := mapping.GroupVersionKind
+
+	doc, err := openAPIDocument(cluster, gvk.GroupVersion())
+	if err != nil {
+		return result.Error(err, "Error in getting openapi schema for %s", gvk.GroupVersion().String())
+	}
+	root := doc.kindSchema(gvk)
+	if root == nil {
+		return result.Invalid(fmt.Sprintf("No openapi schema is published for %s", gvk.String()))
+	}
+	current := doc.resolve(root)
+	for i, name := range path[1:] {
+		next, ok := doc.fieldSchema(current, name)
+		if !ok {
+			output := fmt.Sprintf("Field %s is not found in %s, available fields: %s", name, strings.Join(path[:i+1], "."), strings.Join(doc.fieldNames(current), ", "))
+			return result.Invalid(output)
+		}
+		current = next
+	}
+
+	output := explainData{
+		Kind:        gvk.Kind,
+		APIVersion:  gvk.GroupVersion().String(),
+		Field:       field,
+		Type:        doc.typeName(current),
+		Description: doc.description(current),
+	}
+	properties := doc.properties(current)
+	required := map[string]bool{}
+	for _, name := range doc.required(current) {
+		required[name] = true
+	}
+	for _, name := range doc.fieldNames(current) {
+		output.Fields = append(output.Fields, fieldData{
+			Name:        name,
+			Type:        doc.typeName(properties[name]),
+			Required:    required[name],
+			Description: doc.description(properties[name]),
+		})
+	}
+	return result.JSON(output)
+}
@@ -0,0 +1,184 @@
+package apiresource
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sort"
+	"strings"
+)
+
+// document is the part of an OpenAPI v3 group version document needed to
+// walk the schema of a kind.
+type document struct {
+	Components struct {
+		Schemas map[string]*openAPISchema `json:"schemas"`
+	} `json:"components"`
+}
+
+type openAPISchema struct {
+	Description          string                    `json:"description,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Ref                  string                    `json:"$ref,omitempty"`
+	AllOf                []*openAPISchema          `json:"allOf,omitempty"`
+	Items                *openAPISchema            `json:"items,omitempty"`
+	AdditionalProperties json.RawMessage           `json:"additionalProperties,omitempty"`
+	Properties           map[string]*openAPISchema `json:"properties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	GroupVersionKinds    []struct {
+		Group   string `json:"group"`
+		Version string `json:"version"`
+		Kind    string `json:"kind"`
+	} `json:"x-kubernetes-group-version-kind,omitempty"`
+}
+
+func openAPIDocument(cluster *client.Cluster, gv schema.GroupVersion) (*document, error) {
+	paths, err := cluster.Discovery.OpenAPIV3().Paths()
+	if err != nil {
+		return nil, err
+	}
+	key := "apis/" + gv.Group + "/" + gv.Version
+	if gv.Group == "" {
+		key = "api/" + gv.Version
+	}
+	groupVersion, ok := paths[key]
+	if !ok {
+		return nil, fmt.Errorf("openapi v3 path %s is not served", key)
+	}
+	data, err := groupVersion.Schema("application/json")
+	if err != nil {
+		return nil, err
+	}
+	doc := &document{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func (d *document) kindSchema(gvk schema.GroupVersionKind) *openAPISchema {
+	for _, s := range d.Components.Schemas {
+		for _, candidate := range s.GroupVersionKinds {
+			if candidate.Group == gvk.Group && candidate.Version == gvk.Version && candidate.Kind == gvk.Kind {
+				return s
+			}
+		}
+	}
+	return nil
+}
+
+// resolve follows $ref and single element allOf wrappers to the schema that
+// holds the definition.
+func (d *document) resolve(s *openAPISchema) *openAPISchema {
+	for i := 0; s != nil && i < 10; i++ {
+		switch {
+		case s.Ref != "":
+			s = d.Components.Schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+		case len(s.AllOf) == 1 && s.Type == "" && len(s.Properties) == 0:
+			s = s.AllOf[0]
+		default:
+			return s
+		}
+	}
+	return s
+}
+
+// elem returns the element schema of arrays and maps, which is where the
+// fields of a path like pod.spec.containers.name live.
+func (d *document) elem(s *openAPISchema) *openAPISchema {
+	s = d.resolve(s)
+	if s == nil {
+		return nil
+	}
+	if s.Type == "array" && s.Items != nil {
+		return d.elem(s.Items)
+	}
+	if value := d.additionalProperties(s); value != nil {
+		return d.elem(value)
+	}
+	return s
+}
+
+func (d *document) additionalProperties(s *openAPISchema) *openAPISchema {
+	if s.Type != "object" || len(s.AdditionalProperties) == 0 || len(s.Properties) > 0 {
+		return nil
+	}
+	value := &openAPISchema{}
+	if err := json.Unmarshal(s.AdditionalProperties, value); err != nil {
+		return nil
+	}
+	return value
+}
+
+func (d *document) properties(s *openAPISchema) map[string]*openAPISchema {
+	if elem := d.resolve(d.elem(s)); elem != nil {
+		return elem.Properties
+	}
+	return nil
+}
+
+// required returns the required fields of s, none when its reference does
+// not resolve.
+func (d *document) required(s *openAPISchema) []string {
+	if elem := d.resolve(d.elem(s)); elem != nil {
+		return elem.Required
+	}
+	return nil
+}
+
+func (d *document) fieldSchema(s *openAPISchema, name string) (*openAPISchema, bool) {
+	field, ok := d.properties(s)[name]
+	return field, ok && field != nil
+}
+
+func (d *document) fieldNames(s *openAPISchema) []string {
+	var names []string
+	for name := range d.properties(s) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// description prefers the description set on the field over the one of the
+// type it references.
+func (d *document) description(s *openAPISchema) string {
+	if s == nil {
+		return ""
+	}
+	if s.Description != "" {
+		return s.Description
+	}
+	if resolved := d.resolve(s); resolved != nil {
+		return resolved.Description
+	}
+	return ""
+}
+
+func (d *document) typeName(s *openAPISchema) string {
+	if s == nil {
+		return ""
+	}
+	ref := s.Ref
+	if ref == "" && len(s.AllOf) == 1 {
+		ref = s.AllOf[0].Ref
+	}
+	if ref != "" {
+		name := ref[strings.LastIndex(ref, ".")+1:]
+		if resolved := d.resolve(s); resolved != nil && resolved.Type != "" && resolved.Type != "object" {
+			return resolved.Type
+		}
+		return name
+	}
+	switch {
+	case s.Type == "array" && s.Items != nil:
+		return "[]" + d.typeName(s.Items)
+	case d.additionalProperties(s) != nil:
+		return "map[string]" + d.typeName(d.additionalProperties(s))
+	case s.Format != "":
+		return s.Type + "(" + s.Format + ")"
+	}
+	return s.Type
+}
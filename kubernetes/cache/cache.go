@@ -0,0 +1,360 @@
+package cache
+
+import (
+	"context"
+	"flag"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	appsinformers "k8s.io/client-go/informers/apps/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	toolscache "k8s.io/client-go/tools/cache"
+)
+
+var enabled bool
+var resync time.Duration
+var syncTimeout time.Duration
+
+func init() {
+	flag.BoolVar(&enabled, "cache", false, "Serve the list and get tools of pods, deployments, statefulsets, daemonsets, services, nodes, namespaces, pvcs and pvs from a shared informer cache per cluster, started on first use of each resource")
+	flag.DurationVar(&resync, "cacheResync", 10*time.Minute, "How often the cached objects are resynced, 0 disables the resync")
+	flag.DurationVar(&syncTimeout, "cacheSyncTimeout", 10*time.Second, "How long the first read of a resource waits for its cache to fill before reading from the API server")
+}
+
+// Enabled reports whether --cache is set.
+func Enabled() bool {
+	return enabled
+}
+
+type newInformer func(client kubernetes.Interface, resync time.Duration) toolscache.SharedIndexInformer
+
+var namespaceIndex = toolscache.Indexers{toolscache.NamespaceIndex: toolscache.MetaNamespaceIndexFunc}
+
+// informers are the resources that can be cached. Secrets and configmaps are
+// left out so their data is not kept in memory.
+var informers = map[schema.GroupResource]newInformer{
+	{Resource: "pods"}: func(client kubernetes.Interface, resync time.Duration) toolscache.SharedIndexInformer {
+		return coreinformers.NewPodInformer(client, metav1.NamespaceAll, resync, namespaceIndex)
+	},
+	{Resource: "services"}: func(client kubernetes.Interface, resync time.Duration) toolscache.SharedIndexInformer {
+		return coreinformers.NewServiceInformer(client, metav1.NamespaceAll, resync, namespaceIndex)
+	},
+	{Resource: "persistentvolumeclaims"}: func(client kubernetes.Interface, resync time.Duration) toolscache.SharedIndexInformer {
+		return coreinformers.NewPersistentVolumeClaimInformer(client, metav1.NamespaceAll, resync, namespaceIndex)
+	},
+	{Resource: "nodes"}: func(client kubernetes.Interface, resync time.Duration) toolscache.SharedIndexInformer {
+		return coreinformers.NewNodeInformer(client, resync, toolscache.Indexers{})
+	},
+	{Resource: "namespaces"}: func(client kubernetes.Interface, resync time.Duration) toolscache.SharedIndexInformer {
+		return coreinformers.NewNamespaceInformer(client, resync, toolscache.Indexers{})
+	},
+	{Resource: "persistentvolumes"}: func(client kubernetes.Interface, resync time.Duration) toolscache.SharedIndexInformer {
+		return coreinformers.NewPersistentVolumeInformer(client, resync, toolscache.Indexers{})
+	},
+	{Group: "apps", Resource: "deployments"}: func(client kubernetes.Interface, resync time.Duration) toolscache.SharedIndexInformer {
+		return appsinformers.NewDeploymentInformer(client, metav1.NamespaceAll, resync, namespaceIndex)
+	},
+	{Group: "apps", Resource: "statefulsets"}: func(client kubernetes.Interface, resync time.Duration) toolscache.SharedIndexInformer {
+		return appsinformers.NewStatefulSetInformer(client, metav1.NamespaceAll, resync, namespaceIndex)
+	},
+	{Group: "apps", Resource: "daemonsets"}: func(client kubernetes.Interface, resync time.Duration) toolscache.SharedIndexInformer {
+		return appsinformers.NewDaemonSetInformer(client, metav1.NamespaceAll, resync, namespaceIndex)
+	},
+}
+
+// Cache holds the informers of one cluster. They run with the server
+// credentials, so a Cache must not serve calls that impersonate a caller.
+type Cache struct {
+	context string
+	client  kubernetes.Interface
+
+	mu      sync.Mutex
+	entries map[schema.GroupResource]*entry
+}
+
+// New returns an empty cache for the cluster of the kubeconfig context,
+// informers are started as the resources are read.
+func New(contextName string, client kubernetes.Interface) *Cache {
+	return &Cache{
+		context: contextName,
+		client:  client,
+		entries: map[schema.GroupResource]*entry{},
+	}
+}
+
+// entry is the informer of one resource and how fresh its store is.
+type entry struct {
+	resource schema.GroupResource
+	informer toolscache.SharedIndexInformer
+	stop     chan struct{}
+	synced   chan struct{}
+
+	mu       sync.Mutex
+	updated  time.Time
+	watchErr error
+	disabled bool
+}
+
+// Clientset returns client reading from the cache when ctx is a call of a
+// read-only tool, see Middleware, and client itself otherwise. Reads the
+// cache cannot answer, like paged lists or field selectors, go to the API
+// server.
+func (c *Cache) Clientset(ctx context.Context) kubernetes.Interface {
+	reads := readsFrom(ctx)
+	if reads == nil {
+		return c.client
+	}
+	return &clientset{Interface: c.client, cache: c, reads: reads}
+}
+
+// entry returns the synced entry of resource, starting its informer on first
+// use. It returns nil when the resource has to be read from the API server.
+func (c *Cache) entry(ctx context.Context, resource schema.GroupResource) *entry {
+	c.mu.Lock()
+	e, ok := c.entries[resource]
+	if !ok {
+		newInformer, known := informers[resource]
+		if !known {
+			c.mu.Unlock()
+			return nil
+		}
+		e = c.start(resource, newInformer)
+		c.entries[resource] = e
+	}
+	c.mu.Unlock()
+
+	if ok {
+		// Only the call that started the informer waits for it, the
+		// others read from the API server until it is synced.
+		select {
+		case <-e.synced:
+		default:
+			return nil
+		}
+	} else {
+		select {
+		case <-e.synced:
+		case <-time.After(syncTimeout):
+			log.Printf("cache: %s of context %s is not synced after %s, reading from the API server", resource, c.context, syncTimeout)
+			return nil
+		case <-ctx.Done():
+			return nil
+		}
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.disabled {
+		return nil
+	}
+	return e
+}
+
+func (c *Cache) start(resource schema.GroupResource, newInformer newInformer) *entry {
+	e := &entry{
+		resource: resource,
+		informer: newInformer(c.client, resync),
+		stop:     make(chan struct{}),
+		synced:   make(chan struct{}),
+	}
+	// Managed fields are never returned by the tools, dropping them keeps
+	// the cache smaller.
+	e.informer.SetTransform(func(obj interface{}) (interface{}, error) {
+		if object, err := meta.Accessor(obj); err == nil {
+			object.SetManagedFields(nil)
+		}
+		return obj, nil
+	})
+	e.informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { e.touch() },
+		UpdateFunc: func(interface{}, interface{}) { e.touch() },
+		DeleteFunc: func(interface{}) { e.touch() },
+	})
+	e.informer.SetWatchErrorHandlerWithContext(func(ctx context.Context, r *toolscache.Reflector, err error) {
+		toolscache.DefaultWatchErrorHandler(ctx, r, err)
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		e.watchErr = err
+		if apierrors.IsForbidden(err) && !e.disabled {
+			log.Printf("cache: not caching %s of context %s, listing them is forbidden", resource, c.context)
+			e.disabled = true
+			close(e.stop)
+		}
+	})
+	log.Printf("cache: starting the informer of %s for context %s", resource, c.context)
+	go e.informer.Run(e.stop)
+	go func() {
+		if toolscache.WaitForCacheSync(e.stop, e.informer.HasSynced) {
+			e.touch()
+		}
+		close(e.synced)
+	}()
+	return e
+}
+
+func (e *entry) touch() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.updated = time.Now()
+	e.watchErr = nil
+}
+
+// Read tells how fresh the cached copy of a resource was when a tool call
+// read it. UpdatedAt is the last time the informer received a change or a
+// resync. Stale is set while the watch of the resource is failing, the
+// objects may then be out of date.
+type Read struct {
+	Resource        string    `json:"resource"`
+	Context         string    `json:"context"`
+	ResourceVersion string    `json:"resourceVersion,omitempty"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+	Stale           bool      `json:"stale,omitempty"`
+	Error           string    `json:"error,omitempty"`
+}
+
+func (e *entry) read(contextName string) Read {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	read := Read{
+		Resource:        e.resource.String(),
+		Context:         contextName,
+		ResourceVersion: e.informer.LastSyncResourceVersion(),
+		UpdatedAt:       e.updated.UTC(),
+		Stale:           e.watchErr != nil,
+	}
+	if e.watchErr != nil {
+		read.Error = e.watchErr.Error()
+	}
+	return read
+}
+
+// reads collects the cached reads of one tool call.
+type reads struct {
+	mu    sync.Mutex
+	reads []Read
+}
+
+func (r *reads) add(read Read) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, existing := range r.reads {
+		if existing.Resource == read.Resource && existing.Context == read.Context {
+			r.reads[i] = read
+			return
+		}
+	}
+	r.reads = append(r.reads, read)
+}
+
+type readsKey struct{}
+
+func readsFrom(ctx context.Context) *reads {
+	r, _ := ctx.Value(readsKey{}).(*reads)
+	return r
+}
+
+// Middleware lets the handler of a read-only tool read from the cache. When
+// the call was served from it, the result reports how fresh the cached
+// objects were under "cache" in its _meta.
+func Middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		served := &reads{}
+		res, err := next(context.WithValue(ctx, readsKey{}, served), request)
+		if res == nil || len(served.reads) == 0 {
+			return res, err
+		}
+		if res.Meta == nil {
+			res.Meta = &mcp.Meta{}
+		}
+		if res.Meta.AdditionalFields == nil {
+			res.Meta.AdditionalFields = map[string]any{}
+		}
+		res.Meta.AdditionalFields["cache"] = served.reads
+		return res, err
+	}
+}
+
+// reader reads one resource of one namespace, or of every namespace when
+// namespace is empty, for a tool call.
+type reader struct {
+	cache     *Cache
+	reads     *reads
+	resource  schema.GroupResource
+	namespace string
+}
+
+// list returns the cached objects matching opts. ok is false when the list
+// has to go to the API server. The items share their maps and slices with
+// the cache, the read-only tools must not modify them.
+func list[T any](ctx context.Context, r reader, opts metav1.ListOptions) (items []T, listMeta metav1.ListMeta, ok bool, err error) {
+	if opts.FieldSelector != "" || opts.Limit > 0 || opts.Continue != "" || opts.ResourceVersion != "" {
+		return nil, listMeta, false, nil
+	}
+	e := r.cache.entry(ctx, r.resource)
+	if e == nil {
+		return nil, listMeta, false, nil
+	}
+	selector, err := labels.Parse(opts.LabelSelector)
+	if err != nil {
+		return nil, listMeta, true, apierrors.NewBadRequest(err.Error())
+	}
+	var objects []interface{}
+	if r.namespace == metav1.NamespaceAll {
+		objects = e.informer.GetIndexer().List()
+	} else {
+		objects, err = e.informer.GetIndexer().ByIndex(toolscache.NamespaceIndex, r.namespace)
+		if err != nil {
+			return nil, listMeta, false, nil
+		}
+	}
+	// The API server returns lists ordered by namespace and name.
+	sort.Slice(objects, func(i, j int) bool {
+		a, _ := meta.Accessor(objects[i])
+		b, _ := meta.Accessor(objects[j])
+		if a.GetNamespace() != b.GetNamespace() {
+			return a.GetNamespace() < b.GetNamespace()
+		}
+		return a.GetName() < b.GetName()
+	})
+	items = make([]T, 0, len(objects))
+	for _, obj := range objects {
+		object, err := meta.Accessor(obj)
+		if err != nil || !selector.Matches(labels.Set(object.GetLabels())) {
+			continue
+		}
+		items = append(items, *obj.(*T))
+	}
+	read := e.read(r.cache.context)
+	r.reads.add(read)
+	return items, metav1.ListMeta{ResourceVersion: read.ResourceVersion}, true, nil
+}
+
+// get returns the cached object named name. ok is false when the object has
+// to be read from the API server, which includes objects missing from the
+// cache since they may have been created after the last update.
+func get[T any](ctx context.Context, r reader, name string) (item *T, ok bool) {
+	e := r.cache.entry(ctx, r.resource)
+	if e == nil {
+		return nil, false
+	}
+	key := name
+	if r.namespace != metav1.NamespaceAll {
+		key = r.namespace + "/" + name
+	}
+	obj, exists, err := e.informer.GetIndexer().GetByKey(key)
+	if err != nil || !exists {
+		return nil, false
+	}
+	copied := *obj.(*T)
+	r.reads.add(e.read(r.cache.context))
+	return &copied, true
+}
@@ -0,0 +1,264 @@
+package cache
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	appsv1client "k8s.io/client-go/kubernetes/typed/apps/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// clientset serves the List and Get calls of the cached resources from the
+// cache, every other call goes to the embedded clientset.
+type clientset struct {
+	kubernetes.Interface
+	cache *Cache
+	reads *reads
+}
+
+func (c *clientset) reader(group, resource, namespace string) reader {
+	return reader{
+		cache:     c.cache,
+		reads:     c.reads,
+		resource:  schema.GroupResource{Group: group, Resource: resource},
+		namespace: namespace,
+	}
+}
+
+func (c *clientset) CoreV1() corev1client.CoreV1Interface {
+	return coreV1{CoreV1Interface: c.Interface.CoreV1(), clientset: c}
+}
+
+func (c *clientset) AppsV1() appsv1client.AppsV1Interface {
+	return appsV1{AppsV1Interface: c.Interface.AppsV1(), clientset: c}
+}
+
+type coreV1 struct {
+	corev1client.CoreV1Interface
+	clientset *clientset
+}
+
+func (c coreV1) Pods(namespace string) corev1client.PodInterface {
+	return pods{c.CoreV1Interface.Pods(namespace), c.clientset.reader("", "pods", namespace)}
+}
+
+func (c coreV1) Services(namespace string) corev1client.ServiceInterface {
+	return services{c.CoreV1Interface.Services(namespace), c.clientset.reader("", "services", namespace)}
+}
+
+func (c coreV1) PersistentVolumeClaims(namespace string) corev1client.PersistentVolumeClaimInterface {
+	return pvcs{c.CoreV1Interface.PersistentVolumeClaims(namespace), c.clientset.reader("", "persistentvolumeclaims", namespace)}
+}
+
+func (c coreV1) Nodes() corev1client.NodeInterface {
+	return nodes{c.CoreV1Interface.Nodes(), c.clientset.reader("", "nodes", "")}
+}
+
+func (c coreV1) Namespaces() corev1client.NamespaceInterface {
+	return namespaces{c.CoreV1Interface.Namespaces(), c.clientset.reader("", "namespaces", "")}
+}
+
+func (c coreV1) PersistentVolumes() corev1client.PersistentVolumeInterface {
+	return pvs{c.CoreV1Interface.PersistentVolumes(), c.clientset.reader("", "persistentvolumes", "")}
+}
+
+type appsV1 struct {
+	appsv1client.AppsV1Interface
+	clientset *clientset
+}
+
+func (c appsV1) Deployments(namespace string) appsv1client.DeploymentInterface {
+	return deployments{c.AppsV1Interface.Deployments(namespace), c.clientset.reader("apps", "deployments", namespace)}
+}
+
+func (c appsV1) StatefulSets(namespace string) appsv1client.StatefulSetInterface {
+	return statefulsets{c.AppsV1Interface.StatefulSets(namespace), c.clientset.reader("apps", "statefulsets", namespace)}
+}
+
+func (c appsV1) DaemonSets(namespace string) appsv1client.DaemonSetInterface {
+	return daemonsets{c.AppsV1Interface.DaemonSets(namespace), c.clientset.reader("apps", "daemonsets", namespace)}
+}
+
+type pods struct {
+	corev1client.PodInterface
+	reader reader
+}
+
+func (p pods) List(ctx context.Context, opts metav1.ListOptions) (*v1.PodList, error) {
+	items, listMeta, ok, err := list[v1.Pod](ctx, p.reader, opts)
+	if !ok {
+		return p.PodInterface.List(ctx, opts)
+	}
+	return &v1.PodList{ListMeta: listMeta, Items: items}, err
+}
+
+func (p pods) Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.Pod, error) {
+	if item, ok := get[v1.Pod](ctx, p.reader, name); ok {
+		return item, nil
+	}
+	return p.PodInterface.Get(ctx, name, opts)
+}
+
+type services struct {
+	corev1client.ServiceInterface
+	reader reader
+}
+
+func (s services) List(ctx context.Context, opts metav1.ListOptions) (*v1.ServiceList, error) {
+	items, listMeta, ok, err := list[v1.Service](ctx, s.reader, opts)
+	if !ok {
+		return s.ServiceInterface.List(ctx, opts)
+	}
+	return &v1.ServiceList{ListMeta: listMeta, Items: items}, err
+}
+
+func (s services) Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.Service, error) {
+	if item, ok := get[v1.Service](ctx, s.reader, name); ok {
+		return item, nil
+	}
+	return s.ServiceInterface.Get(ctx, name, opts)
+}
+
+type pvcs struct {
+	corev1client.PersistentVolumeClaimInterface
+	reader reader
+}
+
+func (p pvcs) List(ctx context.Context, opts metav1.ListOptions) (*v1.PersistentVolumeClaimList, error) {
+	items, listMeta, ok, err := list[v1.PersistentVolumeClaim](ctx, p.reader, opts)
+	if !ok {
+		return p.PersistentVolumeClaimInterface.List(ctx, opts)
+	}
+	return &v1.PersistentVolumeClaimList{ListMeta: listMeta, Items: items}, err
+}
+
+func (p pvcs) Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.PersistentVolumeClaim, error) {
+	if item, ok := get[v1.PersistentVolumeClaim](ctx, p.reader, name); ok {
+		return item, nil
+	}
+	return p.PersistentVolumeClaimInterface.Get(ctx, name, opts)
+}
+
+type nodes struct {
+	corev1client.NodeInterface
+	reader reader
+}
+
+func (n nodes) List(ctx context.Context, opts metav1.ListOptions) (*v1.NodeList, error) {
+	items, listMeta, ok, err := list[v1.Node](ctx, n.reader, opts)
+	if !ok {
+		return n.NodeInterface.List(ctx, opts)
+	}
+	return &v1.NodeList{ListMeta: listMeta, Items: items}, err
+}
+
+func (n nodes) Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.Node, error) {
+	if item, ok := get[v1.Node](ctx, n.reader, name); ok {
+		return item, nil
+	}
+	return n.NodeInterface.Get(ctx, name, opts)
+}
+
+type namespaces struct {
+	corev1client.NamespaceInterface
+	reader reader
+}
+
+func (n namespaces) List(ctx context.Context, opts metav1.ListOptions) (*v1.NamespaceList, error) {
+	items, listMeta, ok, err := list[v1.Namespace](ctx, n.reader, opts)
+	if !ok {
+		return n.NamespaceInterface.List(ctx, opts)
+	}
+	return &v1.NamespaceList{ListMeta: listMeta, Items: items}, err
+}
+
+func (n namespaces) Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.Namespace, error) {
+	if item, ok := get[v1.Namespace](ctx, n.reader, name); ok {
+		return item, nil
+	}
+	return n.NamespaceInterface.Get(ctx, name, opts)
+}
+
+type pvs struct {
+	corev1client.PersistentVolumeInterface
+	reader reader
+}
+
+func (p pvs) List(ctx context.Context, opts metav1.ListOptions) (*v1.PersistentVolumeList, error) {
+	items, listMeta, ok, err := list[v1.PersistentVolume](ctx, p.reader, opts)
+	if !ok {
+		return p.PersistentVolumeInterface.List(ctx, opts)
+	}
+	return &v1.PersistentVolumeList{ListMeta: listMeta, Items: items}, err
+}
+
+func (p pvs) Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.PersistentVolume, error) {
+	if item, ok := get[v1.PersistentVolume](ctx, p.reader, name); ok {
+		return item, nil
+	}
+	return p.PersistentVolumeInterface.Get(ctx, name, opts)
+}
+
+type deployments struct {
+	appsv1client.DeploymentInterface
+	reader reader
+}
+
+func (d deployments) List(ctx context.Context, opts metav1.ListOptions) (*appsv1.DeploymentList, error) {
+	items, listMeta, ok, err := list[appsv1.Deployment](ctx, d.reader, opts)
+	if !ok {
+		return d.DeploymentInterface.List(ctx, opts)
+	}
+	return &appsv1.DeploymentList{ListMeta: listMeta, Items: items}, err
+}
+
+func (d deployments) Get(ctx context.Context, name string, opts metav1.GetOptions) (*appsv1.Deployment, error) {
+	if item, ok := get[appsv1.Deployment](ctx, d.reader, name); ok {
+		return item, nil
+	}
+	return d.DeploymentInterface.Get(ctx, name, opts)
+}
+
+type statefulsets struct {
+	appsv1client.StatefulSetInterface
+	reader reader
+}
+
+func (s statefulsets) List(ctx context.Context, opts metav1.ListOptions) (*appsv1.StatefulSetList, error) {
+	items, listMeta, ok, err := list[appsv1.StatefulSet](ctx, s.reader, opts)
+	if !ok {
+		return s.StatefulSetInterface.List(ctx, opts)
+	}
+	return &appsv1.StatefulSetList{ListMeta: listMeta, Items: items}, err
+}
+
+func (s statefulsets) Get(ctx context.Context, name string, opts metav1.GetOptions) (*appsv1.StatefulSet, error) {
+	if item, ok := get[appsv1.StatefulSet](ctx, s.reader, name); ok {
+		return item, nil
+	}
+	return s.StatefulSetInterface.Get(ctx, name, opts)
+}
+
+type daemonsets struct {
+	appsv1client.DaemonSetInterface
+	reader reader
+}
+
+func (d daemonsets) List(ctx context.Context, opts metav1.ListOptions) (*appsv1.DaemonSetList, error) {
+	items, listMeta, ok, err := list[appsv1.DaemonSet](ctx, d.reader, opts)
+	if !ok {
+		return d.DaemonSetInterface.List(ctx, opts)
+	}
+	return &appsv1.DaemonSetList{ListMeta: listMeta, Items: items}, err
+}
+
+func (d daemonsets) Get(ctx context.Context, name string, opts metav1.GetOptions) (*appsv1.DaemonSet, error) {
+	if item, ok := get[appsv1.DaemonSet](ctx, d.reader, name); ok {
+		return item, nil
+	}
+	return d.DaemonSetInterface.Get(ctx, name, opts)
+}
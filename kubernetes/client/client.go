@@ -1,26 +1,420 @@
 package client
 
 import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/naveenthangaraj03/k8s-mcp-server/auth"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/cache"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
-    "k8s.io/client-go/tools/clientcmd"
-    "flag"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 )
 
 var kubeconfigPath string
+var defaultContext string
+var serverURL string
+var token string
+var tokenFile string
+var caFile string
+var insecure bool
+var userClientTTL time.Duration
+
+// Names of the contexts built when no kubeconfig file is used.
+const (
+	tokenContext     = "token"
+	inClusterContext = "in-cluster"
+)
+
+// serviceAccountNamespace holds the namespace of the pod when the server runs
+// in the cluster.
+const serviceAccountNamespace = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
 
 func init() {
-    flag.StringVar(&kubeconfigPath, "kubeconfigPath", "/root/.kube/conf", "Path to kubeconfig file")
+	flag.StringVar(&kubeconfigPath, "kubeconfigPath", "", "Path to kubeconfig file, multiple files can be separated by \",\". Defaults to $KUBECONFIG, then ~/.kube/config, then the in-cluster service account")
+	flag.StringVar(&defaultContext, "context", "", "Kubeconfig context used when a tool call does not name one, defaults to the current context")
+	flag.StringVar(&serverURL, "server", "", "URL of the Kubernetes API server to use with a bearer token (env K8S_MCP_SERVER)")
+	flag.StringVar(&token, "token", "", "Bearer token to authenticate to the API server (env K8S_MCP_TOKEN)")
+	flag.StringVar(&tokenFile, "tokenFile", "", "File holding the bearer token, re-read when it changes (env K8S_MCP_TOKEN_FILE)")
+	flag.StringVar(&caFile, "certificateAuthority", "", "CA certificate file of the API server used with a bearer token (env K8S_MCP_CA_FILE)")
+	flag.BoolVar(&insecure, "insecureSkipTLSVerify", false, "Skip verifying the API server certificate when using a bearer token (env K8S_MCP_INSECURE)")
+	flag.DurationVar(&userClientTTL, "userClientTTL", 10*time.Minute, "How long the clients of an impersonated caller are kept after its last call")
+}
+
+// Cluster holds the clients built for a single kubeconfig context. A Cluster
+// is built once and reused by every tool call that targets the same context,
+// and once per caller when the calls impersonate authenticated users.
+// Discovery results are cached in memory and fetched on first use. Cache is
+// set with --cache on the Cluster of the server credentials only. Namespace
+// is the namespace of the context, "default" when it has none.
+type Cluster struct {
+	Context   string
+	Cluster   string
+	Server    string
+	Namespace string
+	Config    *rest.Config
+	Clientset *kubernetes.Clientset
+	Dynamic   dynamic.Interface
+	Discovery discovery.CachedDiscoveryInterface
+	Mapper    meta.ResettableRESTMapper
+	Cache     *cache.Cache
+}
+
+// RESTMapping resolves a resource argument the way kubectl does: a kind,
+// plural, singular or short name, optionally qualified by group like
+// "deployments.apps" or "certificates.cert-manager.io". apiVersion, when set,
+// pins the group and version. Discovery is refreshed once when nothing
+// matches so recently installed CRDs resolve.
+func (c *Cluster) RESTMapping(resource, apiVersion string) (*meta.RESTMapping, error) {
+	mapping, err := c.restMapping(resource, apiVersion)
+	if meta.IsNoMatchError(err) {
+		c.Mapper.Reset()
+		mapping, err = c.restMapping(resource, apiVersion)
+	}
+	return mapping, err
+}
+
+func (c *Cluster) restMapping(resource, apiVersion string) (*meta.RESTMapping, error) {
+	mapper := restmapper.NewShortcutExpander(c.Mapper, c.Discovery, func(string) {})
+	fullySpecified, groupResource := schema.ParseResourceArg(strings.ToLower(resource))
+
+	gvr := groupResource.WithVersion("")
+	if apiVersion != "" {
+		gv, err := schema.ParseGroupVersion(apiVersion)
+		if err != nil {
+			return nil, err
+		}
+		gvr = schema.GroupVersionResource{Group: gv.Group, Version: gv.Version, Resource: groupResource.Resource}
+	} else if fullySpecified != nil {
+		if gvk, err := mapper.KindFor(*fullySpecified); err == nil {
+			return c.Mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		}
+	}
+	gvk, err := mapper.KindFor(gvr)
+	if err != nil {
+		return nil, err
+	}
+	return c.Mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+}
+
+// registry caches the Cluster of every context, and of every context and
+// impersonated caller. used holds the last call of each caller entry, which
+// is dropped once unused for --userClientTTL.
+type registry struct {
+	mu       sync.Mutex
+	config   *clientcmdapi.Config
+	source   string
+	clusters map[string]*Cluster
+	used     map[string]time.Time
+}
+
+var clients = &registry{clusters: map[string]*Cluster{}, used: map[string]time.Time{}}
+
+// GetClientset returns the clientset for the context or cluster named in the
+// request, falling back to the default context. Read-only tools get a
+// clientset reading from the informer cache when it is enabled and the call
+// does not impersonate a caller.
+func GetClientset(ctx context.Context, request mcp.CallToolRequest) (kubernetes.Interface, error) {
+	cluster, err := GetCluster(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if cluster.Cache != nil {
+		return cluster.Cache.Clientset(ctx), nil
+	}
+	return cluster.Clientset, nil
+}
+
+// GetCluster resolves the optional "context" and "cluster" arguments of the
+// request to a kubeconfig context and returns its cached clients. When ctx
+// carries an authenticated caller the clients impersonate it, so RBAC is
+// enforced for the caller rather than for the server credentials.
+func GetCluster(ctx context.Context, request mcp.CallToolRequest) (*Cluster, error) {
+	return clients.get(request.GetString("context", ""), request.GetString("cluster", ""), auth.UserFrom(ctx))
+}
+
+// ContextName resolves the context the request targets without building its
+// clients.
+func ContextName(request mcp.CallToolRequest) (string, error) {
+	config, err := KubeConfig()
+	if err != nil {
+		return "", err
+	}
+	return resolveContext(config, request.GetString("context", ""), request.GetString("cluster", ""))
+}
+
+// KubeConfig returns the merged kubeconfig of every configured file.
+func KubeConfig() (*clientcmdapi.Config, error) {
+	clients.mu.Lock()
+	defer clients.mu.Unlock()
+	return clients.load()
 }
 
-func InitializeClients() (*kubernetes.Clientset, error) {
+// AuthSource describes where the credentials in use were loaded from. It is
+// empty until the configuration has been loaded.
+func AuthSource() string {
+	clients.mu.Lock()
+	defer clients.mu.Unlock()
+	return clients.source
+}
+
+// DefaultContext returns the context used when a tool call does not name one.
+func DefaultContext() (string, error) {
+	config, err := KubeConfig()
+	if err != nil {
+		return "", err
+	}
+	return resolveContext(config, "", "")
+}
+
+func (r *registry) get(contextName, clusterName string, user *auth.User) (*Cluster, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	config, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	name, err := resolveContext(config, contextName, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	r.evict(now)
+	key := name
+	if user != nil {
+		key = name + "\x00" + user.Key()
+		r.used[key] = now
+	}
+	if cluster, ok := r.clusters[key]; ok {
+		return cluster, nil
+	}
+
+	clientConfig := clientcmd.NewNonInteractiveClientConfig(*config, name, &clientcmd.ConfigOverrides{}, nil)
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("building config for context %s: %w", name, err)
+	}
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return nil, fmt.Errorf("reading the namespace of context %s: %w", name, err)
+	}
+	if user != nil {
+		restConfig.Impersonate = rest.ImpersonationConfig{
+			UserName: user.Name,
+			Groups:   user.Groups,
+		}
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("building clientset for context %s: %w", name, err)
+	}
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("building dynamic client for context %s: %w", name, err)
+	}
+	cachedDiscovery := memory.NewMemCacheClient(clientset.Discovery())
+	cluster := &Cluster{
+		Context:   name,
+		Cluster:   config.Contexts[name].Cluster,
+		Server:    restConfig.Host,
+		Namespace: namespace,
+		Config:    restConfig,
+		Clientset: clientset,
+		Dynamic:   dynamicClient,
+		Discovery: cachedDiscovery,
+		Mapper:    restmapper.NewDeferredDiscoveryRESTMapper(cachedDiscovery),
+	}
+	if cache.Enabled() && user == nil {
+		cluster.Cache = cache.New(name, clientset)
+	}
+	r.clusters[key] = cluster
+	return cluster, nil
+}
+
+// evict drops the clients of the callers that made no call for
+// --userClientTTL. The clients of the server credentials are kept.
+func (r *registry) evict(now time.Time) {
+	for key, last := range r.used {
+		if now.Sub(last) > userClientTTL {
+			delete(r.clusters, key)
+			delete(r.used, key)
+		}
+	}
+}
+
+// load resolves the credentials once, in this order: a bearer token and
+// server URL, the kubeconfig files given by --kubeconfigPath, the files in
+// $KUBECONFIG, ~/.kube/config and finally the in-cluster service account.
+// Every source is turned into a kubeconfig so contexts work the same way.
+func (r *registry) load() (*clientcmdapi.Config, error) {
+	if r.config != nil {
+		return r.config, nil
+	}
+	config, source, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if len(config.Contexts) == 0 {
+		return nil, fmt.Errorf("no context found in %s", source)
+	}
+	log.Printf("kubernetes: using %s", source)
+	r.config = config
+	r.source = source
+	return config, nil
+}
+
+func loadConfig() (*clientcmdapi.Config, string, error) {
+	server := flagOrEnv(serverURL, "K8S_MCP_SERVER")
+	bearer := flagOrEnv(token, "K8S_MCP_TOKEN")
+	bearerFile := flagOrEnv(tokenFile, "K8S_MCP_TOKEN_FILE")
+	if server != "" || bearer != "" || bearerFile != "" {
+		if server == "" {
+			return nil, "", fmt.Errorf("server URL is required with a bearer token, set --server or K8S_MCP_SERVER")
+		}
+		if bearer == "" && bearerFile == "" {
+			return nil, "", fmt.Errorf("bearer token is required with server %s, set --token, --tokenFile, K8S_MCP_TOKEN or K8S_MCP_TOKEN_FILE", server)
+		}
+		cluster := &clientcmdapi.Cluster{
+			Server:                server,
+			CertificateAuthority:  flagOrEnv(caFile, "K8S_MCP_CA_FILE"),
+			InsecureSkipTLSVerify: insecure || os.Getenv("K8S_MCP_INSECURE") == "true",
+		}
+		authInfo := &clientcmdapi.AuthInfo{Token: bearer, TokenFile: bearerFile}
+		source := fmt.Sprintf("bearer token for server %s", server)
+		if bearer == "" {
+			source = fmt.Sprintf("bearer token file %s for server %s", bearerFile, server)
+		}
+		return singleContext(tokenContext, cluster, authInfo), source, nil
+	}
+
+	paths, source, err := kubeconfigPaths()
+	if err != nil {
+		return nil, "", err
+	}
+	if len(paths) > 0 {
+		rules := &clientcmd.ClientConfigLoadingRules{Precedence: paths}
+		config, err := rules.Load()
+		if err != nil {
+			return nil, "", fmt.Errorf("loading %s: %w", source, err)
+		}
+		return config, source, nil
+	}
+
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, "", fmt.Errorf("no kubeconfig found in --kubeconfigPath, $KUBECONFIG or %s and in-cluster config is not available: %w", clientcmd.RecommendedHomeFile, err)
+	}
+	cluster := &clientcmdapi.Cluster{
+		Server:               restConfig.Host,
+		CertificateAuthority: restConfig.TLSClientConfig.CAFile,
+	}
+	authInfo := &clientcmdapi.AuthInfo{TokenFile: restConfig.BearerTokenFile}
+	config := singleContext(inClusterContext, cluster, authInfo)
+	if namespace, err := os.ReadFile(serviceAccountNamespace); err == nil {
+		config.Contexts[inClusterContext].Namespace = strings.TrimSpace(string(namespace))
+	}
+	return config, "in-cluster service account for server " + restConfig.Host, nil
+}
+
+// kubeconfigPaths returns the kubeconfig files to merge. Files passed with
+// --kubeconfigPath must exist, missing files in $KUBECONFIG are skipped the
+// same way kubectl does.
+func kubeconfigPaths() ([]string, string, error) {
+	if kubeconfigPath != "" {
+		var paths []string
+		for _, path := range strings.Split(kubeconfigPath, ",") {
+			if path = strings.TrimSpace(path); path == "" {
+				continue
+			}
+			if _, err := os.Stat(path); err != nil {
+				return nil, "", fmt.Errorf("kubeconfig %s from --kubeconfigPath: %w", path, err)
+			}
+			paths = append(paths, path)
+		}
+		return paths, fmt.Sprintf("kubeconfig %s from --kubeconfigPath", strings.Join(paths, ",")), nil
+	}
+	if env := os.Getenv(clientcmd.RecommendedConfigPathEnvVar); env != "" {
+		var paths []string
+		for _, path := range filepath.SplitList(env) {
+			if _, err := os.Stat(path); err == nil {
+				paths = append(paths, path)
+			}
+		}
+		if len(paths) > 0 {
+			return paths, fmt.Sprintf("kubeconfig %s from $KUBECONFIG", strings.Join(paths, string(filepath.ListSeparator))), nil
+		}
+		log.Printf("kubernetes: no file in $KUBECONFIG=%s exists, skipping it", env)
+	}
+	if _, err := os.Stat(clientcmd.RecommendedHomeFile); err == nil {
+		return []string{clientcmd.RecommendedHomeFile}, "kubeconfig " + clientcmd.RecommendedHomeFile, nil
+	}
+	return nil, "", nil
+}
+
+func singleContext(name string, cluster *clientcmdapi.Cluster, authInfo *clientcmdapi.AuthInfo) *clientcmdapi.Config {
+	config := clientcmdapi.NewConfig()
+	config.Clusters[name] = cluster
+	config.AuthInfos[name] = authInfo
+	config.Contexts[name] = &clientcmdapi.Context{Cluster: name, AuthInfo: name}
+	config.CurrentContext = name
+	return config
+}
+
+func flagOrEnv(value, env string) string {
+	if value != "" {
+		return value
+	}
+	return os.Getenv(env)
+}
 
-    config, err := clientcmd.BuildConfigFromFlags("" , kubeconfigPath)
-    if err != nil {
-        return nil, err
-    }
-    clientset, err := kubernetes.NewForConfig(config)
-    if err != nil {
-        return nil, err
-    }
-    return clientset, nil
+func resolveContext(config *clientcmdapi.Config, contextName, clusterName string) (string, error) {
+	if contextName != "" {
+		if _, ok := config.Contexts[contextName]; !ok {
+			return "", fmt.Errorf("context %s is not found in kubeconfig", contextName)
+		}
+		return contextName, nil
+	}
+	current := defaultContext
+	if current == "" {
+		current = config.CurrentContext
+	}
+	if clusterName != "" {
+		if ctx, ok := config.Contexts[current]; ok && ctx.Cluster == clusterName {
+			return current, nil
+		}
+		var names []string
+		for name, ctx := range config.Contexts {
+			if ctx.Cluster == clusterName {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 {
+			return "", fmt.Errorf("no context found for cluster %s in kubeconfig", clusterName)
+		}
+		sort.Strings(names)
+		return names[0], nil
+	}
+	if current == "" {
+		return "", fmt.Errorf("no current context is set, provide the context or cluster")
+	}
+	if _, ok := config.Contexts[current]; !ok {
+		return "", fmt.Errorf("context %s is not found in kubeconfig", current)
+	}
+	return current, nil
 }
@@ -0,0 +1,52 @@
+package cluster
+
+import (
+	"context"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+	"sort"
+)
+
+type contextData struct {
+	Name       string `json:"name,omitempty"`
+	Cluster    string `json:"cluster,omitempty"`
+	Server     string `json:"server,omitempty"`
+	User       string `json:"user,omitempty"`
+	Namespace  string `json:"namespace,omitempty"`
+	Default    bool   `json:"default,omitempty"`
+	AuthSource string `json:"authSource,omitempty"`
+}
+
+// ListOutput is the output schema of the list-cluster tool.
+var ListOutput = mcp.WithOutputSchema[result.Items[contextData]]()
+
+func ListCluster(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	config, err := client.KubeConfig()
+	if err != nil {
+		return result.Error(err, "Error in loading kubeconfig")
+	}
+	current, _ := client.DefaultContext()
+	source := client.AuthSource()
+
+	var output []contextData
+	for name, kubeContext := range config.Contexts {
+		var server string
+		if cluster, ok := config.Clusters[kubeContext.Cluster]; ok {
+			server = cluster.Server
+		}
+		output = append(output, contextData{
+			Name:       name,
+			Cluster:    kubeContext.Cluster,
+			Server:     server,
+			User:       kubeContext.AuthInfo,
+			Namespace:  kubeContext.Namespace,
+			Default:    name == current,
+			AuthSource: source,
+		})
+	}
+	sort.Slice(output, func(i, j int) bool {
+		return output[i].Name < output[j].Name
+	})
+	return result.List(output)
+}
@@ -3,8 +3,9 @@ package clusterrole
 import (
 	"fmt"
 	"context"
-	"encoding/json"
 	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/paging"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -15,6 +16,12 @@ type crData struct {
 	Rules        []rules   `json:"rules,omitempty"`
 }
 
+// Output schemas of the clusterrole tools.
+var (
+	ListOutput = mcp.WithOutputSchema[result.Items[crData]]()
+	GetOutput  = mcp.WithOutputSchema[crData]()
+)
+
 type rules struct {
 	ApiGroups    []string  `json:"apiGroups,omitempty"`
 	Resources    []string  `json:"resources,omitempty"`
@@ -22,13 +29,13 @@ type rules struct {
 }
 
 func ListCR(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	crs, err := clientset.RbacV1().ClusterRoles().List(context.TODO(), metav1.ListOptions{})
+	crs, err := clientset.RbacV1().ClusterRoles().List(ctx, paging.Options(request, metav1.ListOptions{}))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing clusterrole: %v", err)), nil
+		return result.Error(err, "Error in listing clusterrole")
 	}
 	var output []crData
 	for _, cr := range crs.Items {
@@ -37,26 +44,22 @@ func ListCR(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResu
 			Namespace: cr.Namespace,
 		})
 	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.Page(output, &crs.ListMeta)
 }
 
 func GetCR(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for clusterrole")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	cr, err := clientset.RbacV1().ClusterRoles().Get(context.TODO(), name, metav1.GetOptions{})
+	cr, err := clientset.RbacV1().ClusterRoles().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in getting clusterrole in %s: %v", name, err)), nil
+		return result.Error(err, "Error in getting clusterrole in %s", name)
 	}
 
 	var crRules []rules
@@ -75,9 +78,5 @@ func GetCR(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResul
 		Rules: crRules,
 	}
 
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.JSON(output)
 }
\ No newline at end of file
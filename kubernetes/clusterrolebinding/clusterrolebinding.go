@@ -3,8 +3,9 @@ package clusterrolebinding
 import (
 	"fmt"
 	"context"
-	"encoding/json"
 	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/paging"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -16,6 +17,12 @@ type crbData struct {
 	Subjects     []subjects  `json:"subjects,omitempty"`
 }
 
+// Output schemas of the clusterrolebinding tools.
+var (
+	ListOutput = mcp.WithOutputSchema[result.Items[crbData]]()
+	GetOutput  = mcp.WithOutputSchema[crbData]()
+)
+
 type roleRef struct {
 	ApiGroup     string  `json:"apiGroup,omitempty"`
 	Kind         string  `json:"kind,omitempty"`
@@ -30,13 +37,13 @@ type subjects struct {
 }
 
 func ListCRB(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	crbs, err := clientset.RbacV1().ClusterRoleBindings().List(context.TODO(), metav1.ListOptions{})
+	crbs, err := clientset.RbacV1().ClusterRoleBindings().List(ctx, paging.Options(request, metav1.ListOptions{}))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing clusterrolebinding: %v", err)), nil
+		return result.Error(err, "Error in listing clusterrolebinding")
 	}
 	var output []crbData
 	for _, crb := range crbs.Items {
@@ -45,26 +52,22 @@ func ListCRB(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolRes
 			Namespace: crb.Namespace,
 		})
 	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.Page(output, &crbs.ListMeta)
 }
 
 func GetCRB(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for clusterrolebinding")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	crb, err := clientset.RbacV1().ClusterRoleBindings().Get(context.TODO(), name, metav1.GetOptions{})
+	crb, err := clientset.RbacV1().ClusterRoleBindings().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in getting clusterrolebinding in %s: %v", name, err)), nil
+		return result.Error(err, "Error in getting clusterrolebinding in %s", name)
 	}
 
 	var saDetails []subjects
@@ -92,9 +95,5 @@ func GetCRB(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResu
 	    Subjects: saDetails,
 	}
 
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.JSON(output)
 }
@@ -3,9 +3,11 @@ package configmap
 import (
 	"fmt"
 	"context"
-	"encoding/json"
 	"strings"
 	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/paging"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/options"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/api/core/v1"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -17,19 +19,25 @@ type cmData struct {
 	Data      map[string]string `json:"data,omitempty"`
 }
 
+// Output schemas of the configmap tools.
+var (
+	ListOutput = mcp.WithOutputSchema[result.Items[cmData]]()
+	GetOutput  = mcp.WithOutputSchema[cmData]()
+)
+
 func ListConfigmapInNS (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for configmap")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	configmaps, err := clientset.CoreV1().ConfigMaps(ns).List(context.TODO(), metav1.ListOptions{})
+	configmaps, err := clientset.CoreV1().ConfigMaps(ns).List(ctx, paging.Options(request, metav1.ListOptions{}))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing configmaps in %s: %v", ns, err)), nil
+		return result.Error(err, "Error in listing configmaps in %s", ns)
 	}
 	var output []cmData
 	for _, configmap := range configmaps.Items {
@@ -38,116 +46,103 @@ func ListConfigmapInNS (ctx context.Context, request mcp.CallToolRequest) (*mcp.
 			Namespace: configmap.Namespace,
 		})
 	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.Page(output, &configmaps.ListMeta)
 }
 
 func ListConfigmap (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	namespaces, err := clientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing namespace: %v", err)), nil
-	}
-	var output []cmData
-	for _, namespace := range namespaces.Items {
-		configmaps, err := clientset.CoreV1().ConfigMaps(namespace.Name).List(context.TODO(), metav1.ListOptions{})
+	return paging.AllNamespaces(ctx, request, clientset, "configmaps", metav1.ListOptions{}, func(namespace string, opts metav1.ListOptions) ([]cmData, metav1.ListInterface, error) {
+		configmaps, err := clientset.CoreV1().ConfigMaps(namespace).List(ctx, opts)
 		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Error in listing configmaps in %s: %v", namespace.Name, err)), nil
+			return nil, nil, err
 		}
+		var output []cmData
 		for _, configmap := range configmaps.Items {
 			output = append(output, cmData{
 				Name: configmap.Name,
 				Namespace: configmap.Namespace,
 			})
 		}
-	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+		return output, &configmaps.ListMeta, nil
+	})
 }
 
 func GetConfigmap (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for configmap")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for configmap")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	configmap, err := clientset.CoreV1().ConfigMaps(ns).Get(context.TODO(), name, metav1.GetOptions{})
+	configmap, err := clientset.CoreV1().ConfigMaps(ns).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in getting configmaps in %s/%s: %v", ns, name, err)), nil
+		return result.Error(err, "Error in getting configmaps in %s/%s", ns, name)
 	}
 	output := cmData{
 		Name: configmap.Name,
 		Namespace: configmap.Namespace,
 		Data: configmap.Data,
 	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.JSON(output)
 }
 
 func DeleteConfigmap (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for configmap")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for configmap")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	err = clientset.CoreV1().ConfigMaps(ns).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	err = clientset.CoreV1().ConfigMaps(ns).Delete(ctx, name, options.Delete(request))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in deleting configmaps in %s/%s: %v", ns, name, err)), nil
+		return result.Error(err, "Error in deleting configmaps in %s/%s", ns, name)
 	}
 	output := fmt.Sprintf("Configmap %s/%s is deleted", ns, name)
-	return mcp.NewToolResultText(string(output)), nil
+	if options.IsDryRun(request) {
+		output = fmt.Sprintf("Configmap %s/%s would be deleted, dry run is enabled", ns, name)
+	}
+	return result.Text(output)
 }
 
 func CreateConfigmap (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for configmap creation")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for configmap creation")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	data, err := request.RequireString("data")
 	if err != nil {
 		output := fmt.Sprintf("Provide datas for configmap creation like password=kubernetes123,username=kubernetes")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
 
 	configmapData := make(map[string]string)
@@ -169,10 +164,13 @@ func CreateConfigmap (ctx context.Context, request mcp.CallToolRequest) (*mcp.Ca
 		},
 		Data: configmapData,
 	}
-	createConfigmap, err := clientset.CoreV1().ConfigMaps(ns).Create(context.TODO(), configmap, metav1.CreateOptions{})
+	createConfigmap, err := clientset.CoreV1().ConfigMaps(ns).Create(ctx, configmap, options.Create(request))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in creating configmap in %s/%s: %v", ns, name, err)), nil
+		return result.Error(err, "Error in creating configmap in %s/%s", ns, name)
+	}
+	if options.IsDryRun(request) {
+		return options.DryRunResult(createConfigmap)
 	}
 	output := fmt.Sprintf("Successfully configmap %s/%s is created", createConfigmap.Namespace, createConfigmap.Name)
-	return mcp.NewToolResultText(string(output)), nil
+	return result.Text(output)
 }
\ No newline at end of file
@@ -0,0 +1,157 @@
+package confirm
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/naveenthangaraj03/k8s-mcp-server/auth"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var ttl time.Duration
+
+func init() {
+	flag.DurationVar(&ttl, "confirmationTTL", 2*time.Minute, "How long the confirmation token returned by a destructive tool stays valid")
+}
+
+type previewData struct {
+	Message           string      `json:"message"`
+	ConfirmationToken string      `json:"confirmationToken"`
+	ExpiresAt         time.Time   `json:"expiresAt"`
+	Preview           interface{} `json:"preview"`
+}
+
+// deleteData is what a tool asking for confirmation returns, the preview
+// with its token on the first call and the outcome of the delete once
+// confirmed.
+type deleteData struct {
+	Message           string      `json:"message,omitempty"`
+	ConfirmationToken string      `json:"confirmationToken,omitempty"`
+	ExpiresAt         *time.Time  `json:"expiresAt,omitempty"`
+	Preview           interface{} `json:"preview,omitempty"`
+}
+
+// DeleteOutput is the output schema of the tools asking for confirmation.
+var DeleteOutput = mcp.WithOutputSchema[deleteData]()
+
+type store struct {
+	mu     sync.Mutex
+	tokens map[string]entry
+}
+
+type entry struct {
+	key     string
+	expires time.Time
+}
+
+var tokens = &store{tokens: map[string]entry{}}
+
+// Key binds a confirmation to the caller, the tool, the target cluster
+// arguments and the object being removed, so a token can only confirm the
+// call it was issued for and only for the caller it was issued to.
+func Key(ctx context.Context, request mcp.CallToolRequest, object ...string) string {
+	caller := ""
+	if user := auth.UserFrom(ctx); user != nil {
+		caller = user.Key()
+	}
+	return strings.Join([]string{
+		caller,
+		request.Params.Name,
+		request.GetString("context", ""),
+		request.GetString("cluster", ""),
+		strings.Join(object, "/"),
+	}, "|")
+}
+
+// Token returns the confirmation token passed with the request, if any.
+func Token(request mcp.CallToolRequest) string {
+	return request.GetString("confirmationToken", "")
+}
+
+// Consume validates the token against the call it was issued for. A token can
+// be used only once.
+func Consume(token, key string) error {
+	tokens.mu.Lock()
+	defer tokens.mu.Unlock()
+	tokens.expire()
+
+	issued, ok := tokens.tokens[token]
+	if !ok {
+		return fmt.Errorf("confirmation token is unknown or expired, call the tool again without it to get a new one")
+	}
+	if issued.key != key {
+		return fmt.Errorf("confirmation token was issued for a different call")
+	}
+	delete(tokens.tokens, token)
+	return nil
+}
+
+// PreviewResult issues a confirmation token for key and returns it with the
+// preview of everything the confirmed call will remove.
+func PreviewResult(message, key string, preview interface{}) (*mcp.CallToolResult, error) {
+	token, expires, err := issue(key)
+	if err != nil {
+		return result.Error(err, "Error in issuing confirmation token")
+	}
+	return result.JSON(previewData{
+		Message:           message,
+		ConfirmationToken: token,
+		ExpiresAt:         expires,
+		Preview:           preview,
+	})
+}
+
+func issue(key string) (string, time.Time, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", time.Time{}, err
+	}
+	token := hex.EncodeToString(buf)
+	expires := time.Now().Add(ttl).UTC().Truncate(time.Second)
+
+	tokens.mu.Lock()
+	defer tokens.mu.Unlock()
+	tokens.expire()
+	tokens.tokens[token] = entry{key: key, expires: expires}
+	return token, expires, nil
+}
+
+func (s *store) expire() {
+	now := time.Now()
+	for token, issued := range s.tokens {
+		if now.After(issued.expires) {
+			delete(s.tokens, token)
+		}
+	}
+}
+
+// Names returns the names of the items in a list object, prefixed with the
+// namespace for namespaced objects.
+func Names(list runtime.Object) []string {
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, item := range items {
+		accessor, err := meta.Accessor(item)
+		if err != nil {
+			continue
+		}
+		if accessor.GetNamespace() != "" {
+			names = append(names, accessor.GetNamespace()+"/"+accessor.GetName())
+		} else {
+			names = append(names, accessor.GetName())
+		}
+	}
+	return names
+}
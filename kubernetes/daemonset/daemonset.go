@@ -3,10 +3,12 @@ package daemonset
 import (
 	"fmt"
 	"context"
-	"encoding/json"
 	"strings"
 	"strconv"
 	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/paging"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/options"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
@@ -21,23 +23,28 @@ type daemonsetData struct {
 	ContainerName     []string          `json:"containerName,omitempty"`
 	ContainerImage    []string          `json:"containerImage,omitempty"`
 }
+
+// Output schemas of the daemonset tools.
+var (
+	ListOutput = mcp.WithOutputSchema[result.Items[daemonsetData]]()
+	GetOutput  = mcp.WithOutputSchema[daemonsetData]()
+)
+
 func ListDaemonsetInNS (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for daemonset")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	labels := request.GetString("label", "")
 
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	daemonsets, err := clientset.AppsV1().DaemonSets(ns).List(context.TODO(), metav1.ListOptions{
-		LabelSelector: labels,
-	})
+	daemonsets, err := clientset.AppsV1().DaemonSets(ns).List(ctx, paging.Options(request, metav1.ListOptions{LabelSelector: labels}))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing daemonsets in %s: %v", ns, err)), nil
+		return result.Error(err, "Error in listing daemonsets in %s", ns)
 	}
 	var output []daemonsetData
 	for _, daemonset := range daemonsets.Items {
@@ -48,32 +55,22 @@ func ListDaemonsetInNS (ctx context.Context, request mcp.CallToolRequest) (*mcp.
 			Labels: daemonset.Labels,
 		})
 	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.Page(output, &daemonsets.ListMeta)
 }
 
 func ListDaemonset (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	labels := request.GetString("label", "")
 
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	namespaces, err := clientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing namespace: %v", err)), nil
-	}
-	var output []daemonsetData
-	for _, namespace := range namespaces.Items {
-		daemonsets, err := clientset.AppsV1().DaemonSets(namespace.Name).List(context.TODO(), metav1.ListOptions{
-			LabelSelector: labels,
-		})
+	return paging.AllNamespaces(ctx, request, clientset, "daemonsets", metav1.ListOptions{LabelSelector: labels}, func(namespace string, opts metav1.ListOptions) ([]daemonsetData, metav1.ListInterface, error) {
+		daemonsets, err := clientset.AppsV1().DaemonSets(namespace).List(ctx, opts)
 		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Error in listing daemonsets in %s: %v", namespace.Name, err)), nil
+			return nil, nil, err
 		}
+		var output []daemonsetData
 		for _, daemonset := range daemonsets.Items {
 			output = append(output, daemonsetData{
 				Name: daemonset.Name,
@@ -82,32 +79,28 @@ func ListDaemonset (ctx context.Context, request mcp.CallToolRequest) (*mcp.Call
 				Labels: daemonset.Labels,
 			})
 		}
-	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+		return output, &daemonsets.ListMeta, nil
+	})
 }
 
 func GetDaemonset (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for daemonset")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for daemonset")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	daemonset, err := clientset.AppsV1().DaemonSets(ns).Get(context.TODO(), name, metav1.GetOptions{})
+	daemonset, err := clientset.AppsV1().DaemonSets(ns).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in getting daemonsets in %s/%s: %v", ns, name, err)), nil
+		return result.Error(err, "Error in getting daemonsets in %s/%s", ns, name)
 	}
 	
 	var cName []string
@@ -126,58 +119,57 @@ func GetDaemonset (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallT
 		ContainerImage: cImage,
 	}
 	
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.JSON(output)
 }
 
 func DeleteDaemonset (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for daemonset")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for daemonset")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	err = clientset.AppsV1().DaemonSets(ns).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	err = clientset.AppsV1().DaemonSets(ns).Delete(ctx, name, options.Delete(request))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in deleting daemonsets in %s: %v", ns, err)), nil
+		return result.Error(err, "Error in deleting daemonsets in %s", ns)
 	}
 	output := fmt.Sprintf("Daemonset %s/%s is deleted", ns, name)
-	return mcp.NewToolResultText(string(output)), nil
+	if options.IsDryRun(request) {
+		output = fmt.Sprintf("Daemonset %s/%s would be deleted, dry run is enabled", ns, name)
+	}
+	return result.Text(output)
 }
 
 func UpdateDaemonset (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for daemonset")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for daemonset")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	labels := request.GetString("label", "")
 	annotation := request.GetString("annotation", "")
 	image := request.GetString("image", "")
 	containerName := request.GetString("containerName", "")
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	daemonset, err := clientset.AppsV1().DaemonSets(ns).Get(context.TODO(), name, metav1.GetOptions{})
+	daemonset, err := clientset.AppsV1().DaemonSets(ns).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in getting daemonsets in %s/%s: %v", ns, name, err)), nil
+		return result.Error(err, "Error in getting daemonsets in %s/%s", ns, name)
 	}
 	if labels != "" {
 		m := make(map[string]string)
@@ -191,12 +183,15 @@ func UpdateDaemonset (ctx context.Context, request mcp.CallToolRequest) (*mcp.Ca
 			}
 		}
 		daemonset.Labels = m
-		updateDaemonset, err := clientset.AppsV1().DaemonSets(ns).Update(context.TODO(), daemonset, metav1.UpdateOptions{})
+		updateDaemonset, err := clientset.AppsV1().DaemonSets(ns).Update(ctx, daemonset, options.Update(request))
 		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Error in updating daemonset %s/%s with label %s: %v", ns, name, labels, err)), nil
+			return result.Error(err, "Error in updating daemonset %s/%s with label %s", ns, name, labels)
+		}
+		if options.IsDryRun(request) {
+			return options.DryRunResult(updateDaemonset)
 		}
 		output := fmt.Sprintf("Successfully daemonset %s/%s updated with label %s", updateDaemonset.Namespace, updateDaemonset.Name, labels)
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Text(output)
 	}
 	if annotation != "" {
 		m := make(map[string]string)
@@ -210,26 +205,32 @@ func UpdateDaemonset (ctx context.Context, request mcp.CallToolRequest) (*mcp.Ca
 			}
 		}
 		daemonset.Annotations = m
-		updateDaemonset, err := clientset.AppsV1().DaemonSets(ns).Update(context.TODO(), daemonset, metav1.UpdateOptions{})
+		updateDaemonset, err := clientset.AppsV1().DaemonSets(ns).Update(ctx, daemonset, options.Update(request))
 		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Error in updating daemonset %s/%s with annotation %s: %v", ns, name, annotation, err)), nil
+			return result.Error(err, "Error in updating daemonset %s/%s with annotation %s", ns, name, annotation)
+		}
+		if options.IsDryRun(request) {
+			return options.DryRunResult(updateDaemonset)
 		}
 		output := fmt.Sprintf("Successfully daemonset %s/%s updated with annotaion %s", updateDaemonset.Namespace, updateDaemonset.Name, annotation)
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Text(output)
 	}
 	if image != "" {
 		if len(daemonset.Spec.Template.Spec.Containers) == 1 {
 			daemonset.Spec.Template.Spec.Containers[0].Image = image
-			updateDaemonset, err := clientset.AppsV1().DaemonSets(ns).Update(context.TODO(), daemonset, metav1.UpdateOptions{})
+			updateDaemonset, err := clientset.AppsV1().DaemonSets(ns).Update(ctx, daemonset, options.Update(request))
 			if err != nil {
-				return mcp.NewToolResultText(fmt.Sprintf("Error in updating daemonset %s/%s with image %s: %v", ns, name, image,  err)), nil
+				return result.Error(err, "Error in updating daemonset %s/%s with image %s", ns, name, image)
+			}
+			if options.IsDryRun(request) {
+				return options.DryRunResult(updateDaemonset)
 			}
 			output := fmt.Sprintf("Successfully daemonset %s/%s updated with image %s", updateDaemonset.Namespace, updateDaemonset.Name, image)
-			return mcp.NewToolResultText(string(output)), nil
+			return result.Text(output)
 		} else {
 			if containerName == "" {
 				output := fmt.Sprintf("Daemonset %s/%s has one than one container please provide the container name to update the image", ns, name)
-				return mcp.NewToolResultText(string(output)), nil
+				return result.Invalid(output)
 			} else {
 				var index int = -1
 				for i, c := range daemonset.Spec.Template.Spec.Containers {
@@ -240,49 +241,52 @@ func UpdateDaemonset (ctx context.Context, request mcp.CallToolRequest) (*mcp.Ca
 				}
 				if index == -1 {
 					output := fmt.Sprintf("Container name %s is not found in daemonset %s/%s ",containerName, ns, name)
-					return mcp.NewToolResultText(string(output)), nil
+					return result.Invalid(output)
 				} else {
 					daemonset.Spec.Template.Spec.Containers[index].Image = image
-					updateDaemonset, err := clientset.AppsV1().DaemonSets(ns).Update(context.TODO(), daemonset, metav1.UpdateOptions{})
+					updateDaemonset, err := clientset.AppsV1().DaemonSets(ns).Update(ctx, daemonset, options.Update(request))
 					if err != nil {
-						return mcp.NewToolResultText(fmt.Sprintf("Error in updating daemonset %s/%s with image %s: %v", ns, name, image,  err)), nil
+						return result.Error(err, "Error in updating daemonset %s/%s with image %s", ns, name, image)
+					}
+					if options.IsDryRun(request) {
+						return options.DryRunResult(updateDaemonset)
 					}
 					output := fmt.Sprintf("Successfully daemonset %s/%s updated with image %s", updateDaemonset.Namespace, updateDaemonset.Name, image)
-					return mcp.NewToolResultText(string(output)), nil
+					return result.Text(output)
 				}
 			}
 		}
 	}
 	output := fmt.Sprintf("Mentioned update in daemonset %s/%s is not possible, we are supporting labelling, annotating and image", ns, name)
-	return mcp.NewToolResultText(string(output)), nil
+	return result.Invalid(output)
 }
 
 func CreateDaemonset (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for daemonset")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name,err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for daemonset")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	labels := request.GetString("label", "")
 	containerNames, err := request.RequireString("containerNames")
 	if err != nil {
 		output := fmt.Sprintf("Provide container name for daemonset")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	containerImages, err := request.RequireString("containerImages")
 	if err != nil {
 		output := fmt.Sprintf("Provide image for daemonset")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	containerPorts := request.GetString("containerPorts", "http:8080")
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
 
 	lab := make(map[string]string)
@@ -307,7 +311,7 @@ func CreateDaemonset (ctx context.Context, request mcp.CallToolRequest) (*mcp.Ca
 	cPorts := strings.Split(containerPorts, ",")
 
 	if len(cNames) != len(cImages) {
-		return mcp.NewToolResultText("container name and images counts are not matched"), nil
+		return result.Invalid("container name and images counts are not matched")
 	}
 
 	var containers []v1.Container
@@ -366,10 +370,13 @@ func CreateDaemonset (ctx context.Context, request mcp.CallToolRequest) (*mcp.Ca
             },
         },
 	}
-	deployDaemonset, err := clientset.AppsV1().DaemonSets(ns).Create(context.TODO(), daemonset, metav1.CreateOptions{})
+	deployDaemonset, err := clientset.AppsV1().DaemonSets(ns).Create(ctx, daemonset, options.Create(request))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in deploying daemonset %s/%s: %v", ns, name,  err)), nil
+		return result.Error(err, "Error in deploying daemonset %s/%s", ns, name)
+	}
+	if options.IsDryRun(request) {
+		return options.DryRunResult(deployDaemonset)
 	}
 	output := fmt.Sprintf("Successfully daemonset %s/%s is created", deployDaemonset.Namespace, deployDaemonset.Name)
-	return mcp.NewToolResultText(string(output)), nil
+	return result.Text(output)
 }
\ No newline at end of file
@@ -3,11 +3,17 @@ package deployment
 import (
 	"fmt"
 	"context"
-	"encoding/json"
+	"time"
 	"strings"
 	"strconv"
 	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/paging"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/options"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/view"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/confirm"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -20,94 +26,103 @@ import (
 	 Labels            map[string]string `json:"labels,omitempty"`
 	 ContainerName     []string          `json:"containerName,omitempty"`
 	 ContainerImage    []string          `json:"containerImage,omitempty"`
+	 CreationTimestamp string `json:"creationTimestamp,omitempty"`
  }
+
+// Output schemas of the deployment tools.
+var (
+	ListOutput = mcp.WithOutputSchema[result.Items[deploymentData]]()
+	GetOutput  = mcp.WithOutputSchema[deploymentData]()
+)
+
+// sortKeys are the sortBy values of the deployment list tools.
+var sortKeys = map[string]view.Key[deploymentData]{
+	"name": view.By(func(deployment deploymentData) string { return deployment.Namespace + "/" + deployment.Name }),
+	"creationTimestamp": view.By(func(deployment deploymentData) string { return deployment.CreationTimestamp }),
+}
+
 func ListDeploymentInNS (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for deployment")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	labels := request.GetString("label", "")
+	fieldSelector := request.GetString("fieldSelector", "")
+	deploymentView, err := view.New(request, sortKeys)
+	if err != nil {
+		return result.Invalid(err.Error())
+	}
 
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	deployments, err := clientset.AppsV1().Deployments(ns).List(context.TODO(), metav1.ListOptions{
-		LabelSelector: labels,
-	})
+	deployments, err := clientset.AppsV1().Deployments(ns).List(ctx, paging.Options(request, metav1.ListOptions{LabelSelector: labels, FieldSelector: fieldSelector}))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing deployment %s: %v", ns, err)), nil
+		return result.Error(err, "Error in listing deployment %s", ns)
 	}
 	var output []deploymentData
 	for _, deployment := range deployments.Items {
 		output = append(output, deploymentData{
 			Name: deployment.Name,
+			CreationTimestamp: deployment.CreationTimestamp.UTC().Format(time.RFC3339),
 			Namespace: deployment.Namespace,
 			AvailableInstance: fmt.Sprintf("%d/%d",deployment.Status.ReadyReplicas, *deployment.Spec.Replicas),
 			Labels: deployment.Labels,
 		})
 	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return deploymentView.Apply(result.Page(output, &deployments.ListMeta))
 }
 
 func ListDeployment (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	labels := request.GetString("label", "")
-	clientset, err := client.InitializeClients()
+	fieldSelector := request.GetString("fieldSelector", "")
+	deploymentView, err := view.New(request, sortKeys)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Invalid(err.Error())
 	}
-	namespaces, err := clientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing namespace: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-
-	var output []deploymentData
-	for _, namespace := range namespaces.Items {
-		deployments, err := clientset.AppsV1().Deployments(namespace.Name).List(context.TODO(), metav1.ListOptions{
-			LabelSelector: labels,
-		})
+	return deploymentView.Apply(paging.AllNamespaces(ctx, request, clientset, "deployment", metav1.ListOptions{LabelSelector: labels, FieldSelector: fieldSelector}, func(namespace string, opts metav1.ListOptions) ([]deploymentData, metav1.ListInterface, error) {
+		deployments, err := clientset.AppsV1().Deployments(namespace).List(ctx, opts)
 		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Error in listing deployment %s: %v", namespace.Name, err)), nil
+			return nil, nil, err
 		}
+		var output []deploymentData
 		for _, deployment := range deployments.Items {
 			output = append(output, deploymentData{
 				Name: deployment.Name,
+				CreationTimestamp: deployment.CreationTimestamp.UTC().Format(time.RFC3339),
 				Namespace: deployment.Namespace,
 				AvailableInstance: fmt.Sprintf("%d/%d",deployment.Status.ReadyReplicas, *deployment.Spec.Replicas),
 				Labels: deployment.Labels,
 			})
-		}	
-	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+		}
+		return output, &deployments.ListMeta, nil
+	}))
 }
 
 func GetDeployment (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for deployment")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for deployment")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	deployment, err := clientset.AppsV1().Deployments(ns).Get(context.TODO(), name, metav1.GetOptions{})
+	deployment, err := clientset.AppsV1().Deployments(ns).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in getting deployment %s/%s: %v", ns, name, err)), nil
+		return result.Error(err, "Error in getting deployment %s/%s", ns, name)
 	}
 	var cName []string
 	var cImage []string
@@ -118,6 +133,7 @@ func GetDeployment (ctx context.Context, request mcp.CallToolRequest) (*mcp.Call
 
 	output := deploymentData{
 		Name: deployment.Name,
+		CreationTimestamp: deployment.CreationTimestamp.UTC().Format(time.RFC3339),
 		Namespace: deployment.Namespace,
 		AvailableInstance: fmt.Sprintf("%d/%d",deployment.Status.ReadyReplicas, *deployment.Spec.Replicas),
 		Labels: deployment.Labels,
@@ -125,60 +141,74 @@ func GetDeployment (ctx context.Context, request mcp.CallToolRequest) (*mcp.Call
 		ContainerImage: cImage,
 	}
 	
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.JSON(output)
 }
 
 func DeleteDeployment (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for deployment")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for deployment")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	err = clientset.AppsV1().Deployments(ns).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	key := confirm.Key(ctx, request, ns, name)
+	if !options.IsDryRun(request) {
+		token := confirm.Token(request)
+		if token == "" {
+			preview, err := deletePreview(ctx, clientset, ns, name)
+			if err != nil {
+				return result.Error(err, "Error in preparing delete preview for deployment %s/%s", ns, name)
+			}
+			message := fmt.Sprintf("Deleting deployment %s/%s removes the replicasets and pods listed in the preview. Call delete-deployment again with the confirmationToken to delete it", ns, name)
+			return confirm.PreviewResult(message, key, preview)
+		}
+		if err := confirm.Consume(token, key); err != nil {
+			return result.Error(err, "Deployment %s/%s is not deleted", ns, name)
+		}
+	}
+	err = clientset.AppsV1().Deployments(ns).Delete(ctx, name, options.Delete(request))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in deleting deployment %s/%s: %v", ns, name, err)), nil
+		return result.Error(err, "Error in deleting deployment %s/%s", ns, name)
 	}
 	
 	output := fmt.Sprintf("Deployment %s/%s is deleted", ns, name)
-	return mcp.NewToolResultText(string(output)), nil
+	if options.IsDryRun(request) {
+		output = fmt.Sprintf("Deployment %s/%s would be deleted, dry run is enabled", ns, name)
+	}
+	return result.Text(output)
 }
 
 func UpdateDeployment (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for deployment")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for deployment")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	labels := request.GetString("label", "")
 	annotation := request.GetString("annotation", "")
 	image := request.GetString("image", "")
 	containerName := request.GetString("containerName", "")
 	replica := request.GetInt("replica", -1)
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	deployment, err := clientset.AppsV1().Deployments(ns).Get(context.TODO(), name, metav1.GetOptions{})
+	deployment, err := clientset.AppsV1().Deployments(ns).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in getting deployment %s/%s: %v", ns, name, err)), nil
+		return result.Error(err, "Error in getting deployment %s/%s", ns, name)
 	}
 	if labels != "" {
 		m := make(map[string]string)
@@ -192,12 +222,15 @@ func UpdateDeployment (ctx context.Context, request mcp.CallToolRequest) (*mcp.C
 			}
 		}
 		deployment.Labels = m
-		updateDeployment, err := clientset.AppsV1().Deployments(ns).Update(context.TODO(), deployment, metav1.UpdateOptions{})
+		updateDeployment, err := clientset.AppsV1().Deployments(ns).Update(ctx, deployment, options.Update(request))
 		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Error in updating deployment %s/%s with label %s: %v", ns, name, labels, err)), nil
+			return result.Error(err, "Error in updating deployment %s/%s with label %s", ns, name, labels)
+		}
+		if options.IsDryRun(request) {
+			return options.DryRunResult(updateDeployment)
 		}
 		output := fmt.Sprintf("Successfully deployment %s/%s updated with label %s", updateDeployment.Namespace, updateDeployment.Name, labels)
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Text(output)
 	}
 	if annotation != "" {
 		m := make(map[string]string)
@@ -211,26 +244,32 @@ func UpdateDeployment (ctx context.Context, request mcp.CallToolRequest) (*mcp.C
 			}
 		}
 		deployment.Annotations = m
-		updateDeployment, err := clientset.AppsV1().Deployments(ns).Update(context.TODO(), deployment, metav1.UpdateOptions{})
+		updateDeployment, err := clientset.AppsV1().Deployments(ns).Update(ctx, deployment, options.Update(request))
 		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Error in updating deployment  %s/%s with annotation %s: %v", ns, name, annotation, err)), nil
+			return result.Error(err, "Error in updating deployment  %s/%s with annotation %s", ns, name, annotation)
+		}
+		if options.IsDryRun(request) {
+			return options.DryRunResult(updateDeployment)
 		}
 		output := fmt.Sprintf("Successfully deployment %s/%s updated with annotaion %s", updateDeployment.Namespace, updateDeployment.Name, annotation)
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Text(output)
 	}
 	if image != "" {
 		if len(deployment.Spec.Template.Spec.Containers) == 1 {
 			deployment.Spec.Template.Spec.Containers[0].Image = image
-			updateDeployment, err := clientset.AppsV1().Deployments(ns).Update(context.TODO(), deployment, metav1.UpdateOptions{})
+			updateDeployment, err := clientset.AppsV1().Deployments(ns).Update(ctx, deployment, options.Update(request))
 			if err != nil {
-				return mcp.NewToolResultText(fmt.Sprintf("Error in updating deployment %s/%s with image %s: %v", ns, name, image,  err)), nil
+				return result.Error(err, "Error in updating deployment %s/%s with image %s", ns, name, image)
+			}
+			if options.IsDryRun(request) {
+				return options.DryRunResult(updateDeployment)
 			}
 			output := fmt.Sprintf("Successfully deployment %s/%s updated with image %s", updateDeployment.Namespace, updateDeployment.Name, image)
-			return mcp.NewToolResultText(string(output)), nil
+			return result.Text(output)
 		} else {
 			if containerName == "" {
 				output := fmt.Sprintf("Deployment %s/%s has one than one container please provide the container name to update the image", ns, name)
-				return mcp.NewToolResultText(string(output)), nil
+				return result.Invalid(output)
 			} else {
 				var index int = -1
 				for i, c := range deployment.Spec.Template.Spec.Containers {
@@ -241,15 +280,18 @@ func UpdateDeployment (ctx context.Context, request mcp.CallToolRequest) (*mcp.C
 				}
 				if index == -1 {
 					output := fmt.Sprintf("Container name %s is not found in deployment %s/%s ",containerName, ns, name)
-					return mcp.NewToolResultText(string(output)), nil
+					return result.Invalid(output)
 				} else {
 					deployment.Spec.Template.Spec.Containers[index].Image = image
-					updateDeployment, err := clientset.AppsV1().Deployments(ns).Update(context.TODO(), deployment, metav1.UpdateOptions{})
+					updateDeployment, err := clientset.AppsV1().Deployments(ns).Update(ctx, deployment, options.Update(request))
 					if err != nil {
-						return mcp.NewToolResultText(fmt.Sprintf("Error in updating deployment %s/%s with image %s: %v", ns, name, image,  err)), nil
+						return result.Error(err, "Error in updating deployment %s/%s with image %s", ns, name, image)
+					}
+					if options.IsDryRun(request) {
+						return options.DryRunResult(updateDeployment)
 					}
 					output := fmt.Sprintf("Successfully deployment %s/%s updated with image %s", updateDeployment.Namespace, updateDeployment.Name, image)
-					return mcp.NewToolResultText(string(output)), nil
+					return result.Text(output)
 				}
 			}
 		}
@@ -257,44 +299,47 @@ func UpdateDeployment (ctx context.Context, request mcp.CallToolRequest) (*mcp.C
 	if replica > -1 {
 		replicas := int32(replica)
 		deployment.Spec.Replicas = &replicas
-		updateDeployment, err := clientset.AppsV1().Deployments(ns).Update(context.TODO(), deployment, metav1.UpdateOptions{})
+		updateDeployment, err := clientset.AppsV1().Deployments(ns).Update(ctx, deployment, options.Update(request))
 		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Error in updating deployment %s/%s with replica %d: %v", ns, name, replica,  err)), nil
+			return result.Error(err, "Error in updating deployment %s/%s with replica %d", ns, name, replica)
+		}
+		if options.IsDryRun(request) {
+			return options.DryRunResult(updateDeployment)
 		}
 		output := fmt.Sprintf("Successfully deployment %s/%s updated with replica %d", updateDeployment.Namespace, updateDeployment.Name, replica)
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Text(output)
 	}
 	output := fmt.Sprintf("Mentioned update in deployment %s/%s is not possible, we are supporting labelling, annotating, replica and image", ns, name)
-	return mcp.NewToolResultText(string(output)), nil
+	return result.Invalid(output)
 }
 
 func CreateDeployment (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for deployment")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name,err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for deployment")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	labels := request.GetString("label", "")
 	replica := request.GetInt("replica", 1)
 	containerNames, err := request.RequireString("containerNames")
 	if err != nil {
 		output := fmt.Sprintf("Provide container name for deployment")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	containerImages, err := request.RequireString("containerImages")
 	if err != nil {
 		output := fmt.Sprintf("Provide images for deployment")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	containerPorts := request.GetString("containerPorts", "http:8080")
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
 
 	var depReplica int32
@@ -321,7 +366,7 @@ func CreateDeployment (ctx context.Context, request mcp.CallToolRequest) (*mcp.C
 	cPorts := strings.Split(containerPorts, ",")
 
 	if len(cNames) != len(cImages) {
-		return mcp.NewToolResultText("container name and images counts are not matched"), nil
+		return result.Invalid("container name and images counts are not matched")
 	}
 
 	var containers []v1.Container
@@ -381,10 +426,63 @@ func CreateDeployment (ctx context.Context, request mcp.CallToolRequest) (*mcp.C
             },
         },
 	}
-	deployDeployment, err := clientset.AppsV1().Deployments(ns).Create(context.TODO(), deployment, metav1.CreateOptions{})
+	deployDeployment, err := clientset.AppsV1().Deployments(ns).Create(ctx, deployment, options.Create(request))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in deploying deployment %s/%s with replica %d: %v", ns, name, replica,  err)), nil
+		return result.Error(err, "Error in deploying deployment %s/%s with replica %d", ns, name, replica)
+	}
+	if options.IsDryRun(request) {
+		return options.DryRunResult(deployDeployment)
 	}
 	output := fmt.Sprintf("Successfully deployment %s/%s is created", deployDeployment.Namespace, deployDeployment.Name)
-	return mcp.NewToolResultText(string(output)), nil
-}
\ No newline at end of file
+	return result.Text(output)
+}
+
+type deploymentPreview struct {
+	Name              string   `json:"name"`
+	Namespace         string   `json:"namespace"`
+	AvailableInstance string   `json:"availabeInstance,omitempty"`
+	Replicasets       []string `json:"replicasets,omitempty"`
+	Pods              []string `json:"pods,omitempty"`
+}
+
+// deletePreview lists the replicasets owned by the deployment and the pods
+// matching its selector, which are garbage collected with it.
+func deletePreview(ctx context.Context, clientset kubernetes.Interface, ns, name string) (*deploymentPreview, error) {
+	deployment, err := clientset.AppsV1().Deployments(ns).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	replicasets, err := clientset.AppsV1().ReplicaSets(ns).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var owned []string
+	for _, rs := range replicasets.Items {
+		if ref := metav1.GetControllerOf(&rs); ref != nil && ref.UID == deployment.UID {
+			owned = append(owned, rs.Namespace+"/"+rs.Name)
+		}
+	}
+	pods, err := clientset.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var replicas int32
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return &deploymentPreview{
+		Name: deployment.Name,
+		Namespace: deployment.Namespace,
+		AvailableInstance: fmt.Sprintf("%d/%d", deployment.Status.ReadyReplicas, replicas),
+		Replicasets: owned,
+		Pods: confirm.Names(pods),
+	}, nil
+}
@@ -0,0 +1,181 @@
+package forward
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/naveenthangaraj03/k8s-mcp-server/auth"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+)
+
+// Output schemas of the port-forward tools.
+var (
+	SessionOutput = mcp.WithOutputSchema[Session]()
+	ListOutput    = mcp.WithOutputSchema[result.Items[Session]]()
+)
+
+func StartPortForward(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ns, err := request.RequireString("namespace")
+	if err != nil {
+		return result.Invalid("Provide namespace for port-forward")
+	}
+	podName := request.GetString("pod", "")
+	serviceName := request.GetString("service", "")
+	if (podName == "") == (serviceName == "") {
+		return result.Invalid("Provide either pod or service to port-forward to")
+	}
+	port, err := request.RequireString("port")
+	if err != nil || port == "" {
+		return result.Invalid("Provide port as the number or name of the pod or service port. Ex: 8080 or http")
+	}
+	localPort := request.GetInt("localPort", 0)
+	if localPort < 0 || localPort > 65535 {
+		return result.Invalid("Provide localPort between 1 and 65535, or 0 for a random port")
+	}
+	cluster, err := client.GetCluster(ctx, request)
+	if err != nil {
+		return result.Error(err, "Error in intialize client")
+	}
+	target := Session{Context: cluster.Context, Namespace: ns, Service: serviceName}
+	var remotePort int
+	if serviceName != "" {
+		target.Pod, remotePort, err = serviceTarget(ctx, cluster.Clientset, ns, serviceName, port)
+	} else {
+		target.Pod, remotePort, err = podTarget(ctx, cluster.Clientset, ns, podName, port)
+	}
+	if err != nil {
+		return result.Error(err, "Error in resolving port %s", port)
+	}
+	session, err := sessions.start(cluster, owner(ctx), target, localPort, remotePort)
+	if err != nil {
+		return result.Error(err, "Error in port-forwarding to Pod %s/%s", ns, target.Pod)
+	}
+	return result.JSON(session)
+}
+
+func ListPortForwards(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return result.List(sessions.list(owner(ctx)))
+}
+
+func StopPortForward(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, err := request.RequireString("id")
+	if err != nil {
+		return result.Invalid("Provide id of the port-forward session")
+	}
+	session, ok := sessions.stop(owner(ctx), id)
+	if !ok {
+		return result.Invalid(fmt.Sprintf("Provide the id of an open port-forward session, %s is not one", id))
+	}
+	return result.Text(fmt.Sprintf("Stopped port-forward %s from %s to %s/%s:%d", session.ID, session.Address, session.Namespace, session.Pod, session.RemotePort))
+}
+
+// owner identifies the caller whose sessions a call sees, empty when the
+// server runs without authentication.
+func owner(ctx context.Context) string {
+	if user := auth.UserFrom(ctx); user != nil {
+		return user.Key()
+	}
+	return ""
+}
+
+// podTarget resolves port, a number or the name of a container port, on the
+// pod name.
+func podTarget(ctx context.Context, clientset kubernetes.Interface, namespace, name, port string) (string, int, error) {
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", 0, err
+	}
+	if pod.Status.Phase != v1.PodRunning {
+		return "", 0, fmt.Errorf("pod %s/%s is %s, not Running", namespace, name, pod.Status.Phase)
+	}
+	remote, err := containerPort(pod, intstr.Parse(port))
+	return name, remote, err
+}
+
+// serviceTarget picks a running and ready pod selected by the service name,
+// like kubectl port-forward svc/name does, and resolves port, the number or
+// name of a service port, to the target port on that pod.
+func serviceTarget(ctx context.Context, clientset kubernetes.Interface, namespace, name, port string) (string, int, error) {
+	service, err := clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", 0, err
+	}
+	if len(service.Spec.Selector) == 0 {
+		return "", 0, fmt.Errorf("service %s/%s has no selector, port-forward to one of its pods instead", namespace, name)
+	}
+	var servicePort *v1.ServicePort
+	for i, candidate := range service.Spec.Ports {
+		if candidate.Name == port || strconv.Itoa(int(candidate.Port)) == port {
+			servicePort = &service.Spec.Ports[i]
+			break
+		}
+	}
+	if servicePort == nil {
+		return "", 0, fmt.Errorf("service %s/%s has no port %s", namespace, name, port)
+	}
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(service.Spec.Selector).String(),
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	var ready []*v1.Pod
+	for i := range pods.Items {
+		if pod := &pods.Items[i]; isReady(pod) {
+			ready = append(ready, pod)
+		}
+	}
+	if len(ready) == 0 {
+		return "", 0, fmt.Errorf("service %s/%s has no running and ready pod", namespace, name)
+	}
+	sort.Slice(ready, func(i, j int) bool {
+		return ready[i].Name < ready[j].Name
+	})
+	target := servicePort.TargetPort
+	if target.Type == intstr.Int && target.IntVal == 0 {
+		target = intstr.FromInt32(servicePort.Port)
+	}
+	remote, err := containerPort(ready[0], target)
+	return ready[0].Name, remote, err
+}
+
+// containerPort returns port as a number, looking a named port up in the
+// container ports of pod.
+func containerPort(pod *v1.Pod, port intstr.IntOrString) (int, error) {
+	if port.Type == intstr.Int {
+		if port.IntVal <= 0 || port.IntVal > 65535 {
+			return 0, fmt.Errorf("port %d is out of range", port.IntVal)
+		}
+		return int(port.IntVal), nil
+	}
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			if containerPort.Name == port.StrVal {
+				return int(containerPort.ContainerPort), nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("pod %s/%s has no container port named %s", pod.Namespace, pod.Name, port.StrVal)
+}
+
+func isReady(pod *v1.Pod) bool {
+	if pod.Status.Phase != v1.PodRunning || pod.DeletionTimestamp != nil {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,221 @@
+package forward
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+)
+
+var maxSessions int
+
+func init() {
+	flag.IntVar(&maxSessions, "maxPortForwards", 10, "Maximum number of port-forward sessions open at the same time, 0 disables port-forwarding")
+}
+
+// readyTimeout bounds how long a session waits for its local listener.
+const readyTimeout = 30 * time.Second
+
+// Session is a port-forward from a local port of the server to a port of a
+// pod. Service is set when the pod was picked as a backend of a service.
+type Session struct {
+	ID         string `json:"id"`
+	Context    string `json:"context"`
+	Namespace  string `json:"namespace"`
+	Pod        string `json:"pod"`
+	Service    string `json:"service,omitempty"`
+	Address    string `json:"address"`
+	LocalPort  uint16 `json:"localPort"`
+	RemotePort uint16 `json:"remotePort"`
+	StartedAt  string `json:"startedAt"`
+}
+
+type session struct {
+	Session
+	seq   int
+	owner string
+	stop  chan struct{}
+	once  sync.Once
+}
+
+func (s *session) close() {
+	s.once.Do(func() { close(s.stop) })
+}
+
+// registry tracks the sessions of every caller, a caller only sees and stops
+// its own.
+type registry struct {
+	mu       sync.Mutex
+	sessions map[string]*session
+	next     int
+	starting int
+}
+
+var sessions = &registry{sessions: map[string]*session{}}
+
+// start forwards localPort on 127.0.0.1, a random port when 0, to
+// remotePort of pod and returns once the local listener is ready. The
+// session outlives the tool call until it is stopped, the connection to the
+// pod is lost or the server shuts down.
+func (r *registry) start(cluster *client.Cluster, owner string, target Session, localPort, remotePort int) (Session, error) {
+	if maxSessions <= 0 {
+		return Session{}, fmt.Errorf("port-forwarding is disabled with --maxPortForwards=0")
+	}
+	r.mu.Lock()
+	active := r.starting + len(r.sessions)
+	if active >= maxSessions {
+		r.mu.Unlock()
+		return Session{}, fmt.Errorf("%d port-forward sessions are open, stop one first", active)
+	}
+	r.next++
+	r.starting++
+	seq := r.next
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		r.starting--
+		r.mu.Unlock()
+	}()
+	target.ID = "pf-" + strconv.Itoa(seq)
+
+	dialer, err := newDialer(cluster, target.Namespace, target.Pod)
+	if err != nil {
+		return Session{}, err
+	}
+	s := &session{Session: target, seq: seq, owner: owner, stop: make(chan struct{})}
+	ready := make(chan struct{})
+	ports := []string{strconv.Itoa(localPort) + ":" + strconv.Itoa(remotePort)}
+	forwarder, err := portforward.NewOnAddresses(dialer, []string{"127.0.0.1"}, ports, s.stop, ready, io.Discard, io.Discard)
+	if err != nil {
+		return Session{}, err
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- forwarder.ForwardPorts()
+	}()
+	select {
+	case <-ready:
+	case err := <-done:
+		if err == nil {
+			err = fmt.Errorf("port-forward stopped before it was ready")
+		}
+		return Session{}, err
+	case <-time.After(readyTimeout):
+		s.close()
+		return Session{}, fmt.Errorf("port-forward was not ready after %s", readyTimeout)
+	}
+	forwarded, err := forwarder.GetPorts()
+	if err != nil || len(forwarded) == 0 {
+		s.close()
+		return Session{}, fmt.Errorf("reading the forwarded port: %v", err)
+	}
+	s.LocalPort = forwarded[0].Local
+	s.RemotePort = forwarded[0].Remote
+	s.Address = "127.0.0.1:" + strconv.Itoa(int(s.LocalPort))
+	s.StartedAt = time.Now().UTC().Format(time.RFC3339)
+
+	r.mu.Lock()
+	r.sessions[s.ID] = s
+	output := s.Session
+	r.mu.Unlock()
+	log.Printf("forward: %s started, %s -> %s/%s:%d", s.ID, s.Address, s.Namespace, s.Pod, s.RemotePort)
+
+	// A session that stops on its own, like when the pod goes away, is
+	// forgotten so it no longer counts against --maxPortForwards.
+	go func() {
+		err := <-done
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if r.sessions[s.ID] != s {
+			return
+		}
+		delete(r.sessions, s.ID)
+		if err == nil {
+			err = fmt.Errorf("connection closed")
+		}
+		log.Printf("forward: %s stopped: %v", s.ID, err)
+	}()
+	return output, nil
+}
+
+// list returns the sessions of owner in the order they started.
+func (r *registry) list(owner string) []Session {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var owned []*session
+	for _, s := range r.sessions {
+		if s.owner == owner {
+			owned = append(owned, s)
+		}
+	}
+	sort.Slice(owned, func(i, j int) bool {
+		return owned[i].seq < owned[j].seq
+	})
+	output := make([]Session, 0, len(owned))
+	for _, s := range owned {
+		output = append(output, s.Session)
+	}
+	return output
+}
+
+// stop stops the session id of owner and forgets it. It reports false when
+// owner has no such session.
+func (r *registry) stop(owner, id string) (Session, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.sessions[id]
+	if !ok || s.owner != owner {
+		return Session{}, false
+	}
+	s.close()
+	delete(r.sessions, id)
+	log.Printf("forward: %s stopped", id)
+	return s.Session, true
+}
+
+// StopAll stops every session, it is called when the server shuts down.
+func StopAll() {
+	sessions.mu.Lock()
+	defer sessions.mu.Unlock()
+	if len(sessions.sessions) > 0 {
+		log.Printf("forward: stopping %d port-forward sessions", len(sessions.sessions))
+	}
+	for id, s := range sessions.sessions {
+		s.close()
+		delete(sessions.sessions, id)
+	}
+}
+
+// newDialer returns the dialer of the portforward subresource of pod, over
+// WebSocket with a fallback to SPDY for older API servers.
+func newDialer(cluster *client.Cluster, namespace, pod string) (httpstream.Dialer, error) {
+	url := cluster.Clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod).
+		SubResource("portforward").
+		URL()
+	transport, upgrader, err := spdy.RoundTripperFor(cluster.Config)
+	if err != nil {
+		return nil, err
+	}
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+	tunneling, err := portforward.NewSPDYOverWebsocketDialer(url, cluster.Config)
+	if err != nil {
+		return nil, err
+	}
+	return portforward.NewFallbackDialer(tunneling, dialer, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	}), nil
+}
@@ -0,0 +1,207 @@
+package manifest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/options"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+	"io"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+	"strings"
+)
+
+const defaultFieldManager = "k8s-mcp-server"
+
+type applyData struct {
+	Kind       string                 `json:"kind,omitempty"`
+	APIVersion string                 `json:"apiVersion,omitempty"`
+	Namespace  string                 `json:"namespace,omitempty"`
+	Name       string                 `json:"name,omitempty"`
+	Result     string                 `json:"result"`
+	Error      string                 `json:"error,omitempty"`
+	Reason     metav1.StatusReason    `json:"reason,omitempty"`
+	DryRun     bool                   `json:"dryRun,omitempty"`
+	Object     map[string]interface{} `json:"object,omitempty"`
+}
+
+// ApplyOutput is the output schema of the apply-manifest tool.
+var ApplyOutput = mcp.WithOutputSchema[result.Items[applyData]]()
+
+func ApplyManifest(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	manifest, err := request.RequireString("manifest")
+	if err != nil {
+		output := fmt.Sprintf("Provide the YAML or JSON manifest to apply")
+		return result.Invalid(output)
+	}
+	ns := request.GetString("namespace", "")
+	fieldManager := request.GetString("fieldManager", defaultFieldManager)
+	force := request.GetBool("force", false)
+
+	objects, err := decode(manifest)
+	if err != nil {
+		return result.Error(err, "Error in parsing manifest")
+	}
+	if len(objects) == 0 {
+		return result.Invalid("Manifest has no object to apply")
+	}
+	cluster, err := client.GetCluster(ctx, request)
+	if err != nil {
+		return result.Error(err, "Error in intialize client")
+	}
+
+	patchOptions := metav1.PatchOptions{
+		FieldManager: fieldManager,
+		Force:        &force,
+		DryRun:       options.DryRun(request),
+	}
+	var output []applyData
+	failed := false
+	for _, object := range objects {
+		data := applyData{
+			Kind:       object.GetKind(),
+			APIVersion: object.GetAPIVersion(),
+			Name:       object.GetName(),
+		}
+		outcome, applied, err := apply(ctx, cluster, object, ns, patchOptions)
+		data.Namespace = object.GetNamespace()
+		if err != nil {
+			data.Result = "failed"
+			data.Error = err.Error()
+			data.Reason = apierrors.ReasonForError(err)
+			failed = true
+		} else {
+			data.Result = outcome
+		}
+		// With dryRun nothing is persisted, the item carries the object the
+		// server would have stored instead.
+		if len(patchOptions.DryRun) > 0 {
+			data.DryRun = true
+			if applied != nil {
+				data.Object = applied.Object
+			}
+		}
+		output = append(output, data)
+	}
+	// The objects applied before a failure stay applied, the result still
+	// lists every object but is marked as failed.
+	applied, err := result.List(output)
+	if applied != nil {
+		applied.IsError = failed
+	}
+	return applied, err
+}
+
+// apply server-side applies one object and reports whether it was created,
+// configured or left unchanged, along with the object the server returned.
+func apply(ctx context.Context, cluster *client.Cluster, object *unstructured.Unstructured, ns string, patchOptions metav1.PatchOptions) (string, *unstructured.Unstructured, error) {
+	if object.GetName() == "" {
+		return "", nil, errors.New("metadata.name is required for server-side apply")
+	}
+	resource, err := resourceFor(cluster, object, ns)
+	if err != nil {
+		return "", nil, err
+	}
+	existing, err := resource.Get(ctx, object.GetName(), metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return "", nil, err
+	}
+	if apierrors.IsNotFound(err) {
+		existing = nil
+	}
+	body, err := json.Marshal(object)
+	if err != nil {
+		return "", nil, err
+	}
+	applied, err := resource.Patch(ctx, object.GetName(), types.ApplyPatchType, body, patchOptions)
+	if err != nil {
+		return "", nil, err
+	}
+	switch {
+	case existing == nil:
+		return "created", applied, nil
+	case unchanged(existing, applied):
+		return "unchanged", applied, nil
+	default:
+		return "configured", applied, nil
+	}
+}
+
+// resourceFor maps the object kind to its resource, retrying once with fresh
+// discovery so kinds from CRDs applied earlier in the manifest resolve.
+func resourceFor(cluster *client.Cluster, object *unstructured.Unstructured, ns string) (dynamic.ResourceInterface, error) {
+	gvk := object.GroupVersionKind()
+	mapping, err := cluster.Mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		cluster.Mapper.Reset()
+		mapping, err = cluster.Mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		object.SetNamespace("")
+		return cluster.Dynamic.Resource(mapping.Resource), nil
+	}
+	if object.GetNamespace() == "" {
+		if ns == "" {
+			ns = metav1.NamespaceDefault
+		}
+		object.SetNamespace(ns)
+	}
+	return cluster.Dynamic.Resource(mapping.Resource).Namespace(object.GetNamespace()), nil
+}
+
+// unchanged compares the object before and after the apply, ignoring the
+// managed fields whose timestamps move on every apply.
+func unchanged(before, after *unstructured.Unstructured) bool {
+	before = before.DeepCopy()
+	after = after.DeepCopy()
+	before.SetManagedFields(nil)
+	after.SetManagedFields(nil)
+	return equality.Semantic.DeepEqual(before.Object, after.Object)
+}
+
+// decode reads every YAML or JSON document of the manifest. Documents of kind
+// List are expanded into their items.
+func decode(manifest string) ([]*unstructured.Unstructured, error) {
+	decoder := yaml.NewYAMLOrJSONDecoder(strings.NewReader(manifest), 4096)
+	var objects []*unstructured.Unstructured
+	for i := 1; ; i++ {
+		var raw map[string]interface{}
+		if err := decoder.Decode(&raw); err != nil {
+			if err == io.EOF {
+				return objects, nil
+			}
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+		if len(raw) == 0 {
+			continue
+		}
+		object := &unstructured.Unstructured{Object: raw}
+		if object.GetAPIVersion() == "" || object.GetKind() == "" {
+			return nil, fmt.Errorf("document %d: apiVersion and kind are required", i)
+		}
+		if object.IsList() {
+			list, err := object.ToList()
+			if err != nil {
+				return nil, fmt.Errorf("document %d: %w", i, err)
+			}
+			for j := range list.Items {
+				objects = append(objects, &list.Items[j])
+			}
+			continue
+		}
+		objects = append(objects, object)
+	}
+}
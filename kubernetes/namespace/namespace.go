@@ -3,10 +3,15 @@ package namespace
 import (
 	"fmt"
 	"context"
-	"encoding/json"
 	"strings"
 	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/paging"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/options"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/confirm"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 	v1 "k8s.io/api/core/v1"
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -16,14 +21,20 @@ type namespaceData struct {
 	Status string `json:"status,omitempty"`
 }
 
+// Output schemas of the namespace tools.
+var (
+	ListOutput = mcp.WithOutputSchema[result.Items[namespaceData]]()
+	GetOutput  = mcp.WithOutputSchema[namespaceData]()
+)
+
 func ListNS (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	namespaces, err := clientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
+	namespaces, err := clientset.CoreV1().Namespaces().List(ctx, paging.Options(request, metav1.ListOptions{}))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing namespace: %v", err)), nil
+		return result.Error(err, "Error in listing namespace")
 	}
 	var output []namespaceData
 	for _, namespace := range namespaces.Items {
@@ -32,71 +43,81 @@ func ListNS (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolRes
 			Status: string(namespace.Status.Phase),
 		})
 	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.Page(output, &namespaces.ListMeta)
 }
 
 func GetNS (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace name to get")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	namespace, err := clientset.CoreV1().Namespaces().Get(context.TODO(), name, metav1.GetOptions{})
+	namespace, err := clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in gettting the namespace %s: %v", name, err)), nil
+		return result.Error(err, "Error in gettting the namespace %s", name)
 	}
 	output := namespaceData{
 		Name: namespace.Name,
 		Status: string(namespace.Status.Phase),
 	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.JSON(output)
 }
 
 func DeleteNS (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace name to delete")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
+	}
+	key := confirm.Key(ctx, request, name)
+	if !options.IsDryRun(request) {
+		token := confirm.Token(request)
+		if token == "" {
+			preview, err := deletePreview(ctx, clientset, name)
+			if err != nil {
+				return result.Error(err, "Error in preparing delete preview for namespace %s", name)
+			}
+			message := fmt.Sprintf("Deleting namespace %s removes every object listed in the preview. Call delete-ns again with the confirmationToken to delete it", name)
+			return confirm.PreviewResult(message, key, preview)
+		}
+		if err := confirm.Consume(token, key); err != nil {
+			return result.Error(err, "Namespace %s is not deleted", name)
+		}
 	}
-	err = clientset.CoreV1().Namespaces().Delete(context.TODO(), name, metav1.DeleteOptions{})
+	err = clientset.CoreV1().Namespaces().Delete(ctx, name, options.Delete(request))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in deleting the namespace %s: %v", name, err)), nil
+		return result.Error(err, "Error in deleting the namespace %s", name)
 	}
 	output := fmt.Sprintf("Namespace %s is deleted", name)
-	return mcp.NewToolResultText(string(output)), nil
+	if options.IsDryRun(request) {
+		output = fmt.Sprintf("Namespace %s would be deleted, dry run is enabled", name)
+	}
+	return result.Text(output)
 }
 
 func UpdateNS (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace name to update")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	labels := request.GetString("label", "")
 	annotation := request.GetString("annotation", "")
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	namespace, err := clientset.CoreV1().Namespaces().Get(context.TODO(), name, metav1.GetOptions{})
+	namespace, err := clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in gettting the namespace %s: %v", name, err)), nil
+		return result.Error(err, "Error in gettting the namespace %s", name)
 	}
 	if labels != "" {
 		m := make(map[string]string)
@@ -110,12 +131,15 @@ func UpdateNS (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolR
 			}
 		}
 		namespace.Labels = m
-		updateNamespace, err := clientset.CoreV1().Namespaces().Update(context.TODO(), namespace, metav1.UpdateOptions{})
+		updateNamespace, err := clientset.CoreV1().Namespaces().Update(ctx, namespace, options.Update(request))
 		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Error in updating namesapce %s with label %s: %v", name, labels, err)), nil
+			return result.Error(err, "Error in updating namesapce %s with label %s", name, labels)
+		}
+		if options.IsDryRun(request) {
+			return options.DryRunResult(updateNamespace)
 		}
 		output := fmt.Sprintf("Successfully namespace %s updated with label %s", updateNamespace.Name, labels)
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Text(output)
 	}
 	if annotation != "" {
 		m := make(map[string]string)
@@ -129,27 +153,30 @@ func UpdateNS (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolR
 			}
 		}
 		namespace.Annotations = m
-		updateNamespace, err := clientset.CoreV1().Namespaces().Update(context.TODO(), namespace, metav1.UpdateOptions{})
+		updateNamespace, err := clientset.CoreV1().Namespaces().Update(ctx, namespace, options.Update(request))
 		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Error in updating namespace %s with annotation %s: %v", name, annotation, err)), nil
+			return result.Error(err, "Error in updating namespace %s with annotation %s", name, annotation)
+		}
+		if options.IsDryRun(request) {
+			return options.DryRunResult(updateNamespace)
 		}
 		output := fmt.Sprintf("Successfully namespace %s updated with annotaion %s",  updateNamespace.Name, annotation)
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Text(output)
 	}
 	output := fmt.Sprintf("Mentioned update in namespace %s is not possible, we are supporting labelling and  annotating",  name)
-	return mcp.NewToolResultText(string(output)), nil
+	return result.Invalid(output)
 }
 
 func CreateNS (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace name to create")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	labels := request.GetString("label", "")
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
 
 	lab := make(map[string]string)
@@ -172,10 +199,83 @@ func CreateNS (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolR
 		},
 	}
 
-	createNamespace, err := clientset.CoreV1().Namespaces().Create(context.TODO(), namespace, metav1.CreateOptions{})
+	createNamespace, err := clientset.CoreV1().Namespaces().Create(ctx, namespace, options.Create(request))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in creating namespace %s: %v", name, err)), nil
+		return result.Error(err, "Error in creating namespace %s", name)
+	}
+	if options.IsDryRun(request) {
+		return options.DryRunResult(createNamespace)
 	}
 	output := fmt.Sprintf("Successfully namespace %s is created",  createNamespace.Name)
-	return mcp.NewToolResultText(string(output)), nil
-}
\ No newline at end of file
+	return result.Text(output)
+}
+
+type namespacePreview struct {
+	Name                   string   `json:"name"`
+	Status                 string   `json:"status,omitempty"`
+	Pods                   []string `json:"pods,omitempty"`
+	PersistentVolumeClaims []string `json:"persistentVolumeClaims,omitempty"`
+	Deployments            []string `json:"deployments,omitempty"`
+	Statefulsets           []string `json:"statefulsets,omitempty"`
+	Daemonsets             []string `json:"daemonsets,omitempty"`
+	Services               []string `json:"services,omitempty"`
+	Configmaps             []string `json:"configmaps,omitempty"`
+	Secrets                []string `json:"secrets,omitempty"`
+	ServiceAccounts        []string `json:"serviceAccounts,omitempty"`
+	NotListed              []string `json:"notListed,omitempty"`
+}
+
+// deletePreview lists the objects removed together with the namespace. Kinds
+// the caller cannot list are reported in NotListed instead of failing.
+func deletePreview(ctx context.Context, clientset kubernetes.Interface, name string) (*namespacePreview, error) {
+	namespace, err := clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	preview := &namespacePreview{
+		Name: namespace.Name,
+		Status: string(namespace.Status.Phase),
+	}
+	listers := []struct {
+		kind   string
+		target *[]string
+		list   func() (runtime.Object, error)
+	}{
+		{"pods", &preview.Pods, func() (runtime.Object, error) {
+			return clientset.CoreV1().Pods(name).List(ctx, metav1.ListOptions{})
+		}},
+		{"persistentvolumeclaims", &preview.PersistentVolumeClaims, func() (runtime.Object, error) {
+			return clientset.CoreV1().PersistentVolumeClaims(name).List(ctx, metav1.ListOptions{})
+		}},
+		{"deployments", &preview.Deployments, func() (runtime.Object, error) {
+			return clientset.AppsV1().Deployments(name).List(ctx, metav1.ListOptions{})
+		}},
+		{"statefulsets", &preview.Statefulsets, func() (runtime.Object, error) {
+			return clientset.AppsV1().StatefulSets(name).List(ctx, metav1.ListOptions{})
+		}},
+		{"daemonsets", &preview.Daemonsets, func() (runtime.Object, error) {
+			return clientset.AppsV1().DaemonSets(name).List(ctx, metav1.ListOptions{})
+		}},
+		{"services", &preview.Services, func() (runtime.Object, error) {
+			return clientset.CoreV1().Services(name).List(ctx, metav1.ListOptions{})
+		}},
+		{"configmaps", &preview.Configmaps, func() (runtime.Object, error) {
+			return clientset.CoreV1().ConfigMaps(name).List(ctx, metav1.ListOptions{})
+		}},
+		{"secrets", &preview.Secrets, func() (runtime.Object, error) {
+			return clientset.CoreV1().Secrets(name).List(ctx, metav1.ListOptions{})
+		}},
+		{"serviceaccounts", &preview.ServiceAccounts, func() (runtime.Object, error) {
+			return clientset.CoreV1().ServiceAccounts(name).List(ctx, metav1.ListOptions{})
+		}},
+	}
+	for _, lister := range listers {
+		list, err := lister.list()
+		if err != nil {
+			preview.NotListed = append(preview.NotListed, fmt.Sprintf("%s: %v", lister.kind, err))
+			continue
+		}
+		*lister.target = confirm.Names(list)
+	}
+	return preview, nil
+}
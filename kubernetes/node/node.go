@@ -3,10 +3,16 @@ package node
 import (
 	"fmt"
 	"context"
-	"encoding/json"
+	"time"
 	"strings"
 	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/paging"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/options"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/view"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/confirm"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
@@ -17,16 +23,34 @@ type nodeData struct {
 	OS                string `json:"os,omitempty"`
 	KernelVersion     string `json:"kernelVersion,omitempty"`
 	Architecture      string `json:"architecture,omitempty"`
+	CreationTimestamp string `json:"creationTimestamp,omitempty"`
+}
+
+// Output schemas of the node tools.
+var (
+	ListOutput = mcp.WithOutputSchema[result.Items[nodeData]]()
+	GetOutput  = mcp.WithOutputSchema[nodeData]()
+)
+
+// sortKeys are the sortBy values of the node list tools.
+var sortKeys = map[string]view.Key[nodeData]{
+	"name": view.By(func(node nodeData) string { return node.Name }),
+	"creationTimestamp": view.By(func(node nodeData) string { return node.CreationTimestamp }),
 }
 
 func ListNode (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	clientset, err := client.InitializeClients()
+	fieldSelector := request.GetString("fieldSelector", "")
+	nodeView, err := view.New(request, sortKeys)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Invalid(err.Error())
 	}
-	nodes, err := clientset.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing node: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
+	}
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, paging.Options(request, metav1.ListOptions{FieldSelector: fieldSelector}))
+	if err != nil {
+		return result.Error(err, "Error in listing node")
 	}
 	var output []nodeData
 	for _, node := range nodes.Items {
@@ -42,29 +66,26 @@ func ListNode (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolR
 		}
 		output = append(output, nodeData{
 			Name: node.Name,
+			CreationTimestamp: node.CreationTimestamp.UTC().Format(time.RFC3339),
 			Status: nodeStatus,
 		})
 	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return nodeView.Apply(result.Page(output, &nodes.ListMeta))
 }
 
 func GetNode (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for node")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	node, err := clientset.CoreV1().Nodes().Get(context.TODO(), name, metav1.GetOptions{})
+	node, err := clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in getting node: %v", err)), nil
+		return result.Error(err, "Error in getting node")
 	}
 	var nodeStatus string
 	for _, v := range node.Status.Conditions {
@@ -78,55 +99,70 @@ func GetNode (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolRe
 	}
 	output := nodeData{
 		Name: node.Name,
+		CreationTimestamp: node.CreationTimestamp.UTC().Format(time.RFC3339),
 		Status: nodeStatus,
 		KubernetesVersion: node.Status.NodeInfo.KubeletVersion,
 		OS: node.Status.NodeInfo.OSImage,
 		KernelVersion: node.Status.NodeInfo.KernelVersion,
 		Architecture: node.Status.NodeInfo.Architecture,
 	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.JSON(output)
 }
 
 func DeleteNode (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for node")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
+	}
+	key := confirm.Key(ctx, request, name)
+	if !options.IsDryRun(request) {
+		token := confirm.Token(request)
+		if token == "" {
+			preview, err := deletePreview(ctx, clientset, name)
+			if err != nil {
+				return result.Error(err, "Error in preparing delete preview for node %s", name)
+			}
+			message := fmt.Sprintf("Deleting node %s removes it from the cluster, the pods listed in the preview are bound to it. Call delete-node again with the confirmationToken to delete it", name)
+			return confirm.PreviewResult(message, key, preview)
+		}
+		if err := confirm.Consume(token, key); err != nil {
+			return result.Error(err, "Node %s is not deleted", name)
+		}
 	}
-	err = clientset.CoreV1().Nodes().Delete(context.TODO(), name, metav1.DeleteOptions{})
+	err = clientset.CoreV1().Nodes().Delete(ctx, name, options.Delete(request))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in deleting node: %v", err)), nil
+		return result.Error(err, "Error in deleting node")
 	}
 	output := fmt.Sprintf("Node %s is deleted", name)
-	return mcp.NewToolResultText(string(output)), nil
+	if options.IsDryRun(request) {
+		output = fmt.Sprintf("Node %s would be deleted, dry run is enabled", name)
+	}
+	return result.Text(output)
 }
 
 func UpdateNode (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for node")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	labels, err := request.RequireString("label")
 	if err != nil {
 		output := fmt.Sprintf("Provide label for node")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	node, err := clientset.CoreV1().Nodes().Get(context.TODO(), name, metav1.GetOptions{})
+	node, err := clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in getting node: %v", err)), nil
+		return result.Error(err, "Error in getting node")
 	}
 	m := make(map[string]string)
 	label := strings.Split(labels, ",")
@@ -139,10 +175,51 @@ func UpdateNode (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToo
 		}
 	}
 	node.Labels = m
-	updateNode, err := clientset.CoreV1().Nodes().Update(context.TODO(), node, metav1.UpdateOptions{})
+	updateNode, err := clientset.CoreV1().Nodes().Update(ctx, node, options.Update(request))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in updating node %s with label %s: %v", name, labels, err)), nil
+		return result.Error(err, "Error in updating node %s with label %s", name, labels)
+	}
+	if options.IsDryRun(request) {
+		return options.DryRunResult(updateNode)
 	}
 	output := fmt.Sprintf("Successfully node %s updated with label %s", updateNode.Name, labels)
-	return mcp.NewToolResultText(string(output)), nil
-}
\ No newline at end of file
+	return result.Text(output)
+}
+
+type nodePreview struct {
+	Name          string   `json:"name"`
+	Status        string   `json:"status,omitempty"`
+	Unschedulable bool     `json:"unschedulable,omitempty"`
+	Pods          []string `json:"pods,omitempty"`
+}
+
+// deletePreview shows the node and the pods bound to it, which lose their
+// node once it is removed.
+func deletePreview(ctx context.Context, clientset kubernetes.Interface, name string) (*nodePreview, error) {
+	node, err := clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var nodeStatus string
+	for _, v := range node.Status.Conditions {
+		if v.Type == "Ready" {
+			if v.Status == "True" {
+				nodeStatus = "Ready"
+			} else {
+				nodeStatus = "NotReady"
+			}
+		}
+	}
+	pods, err := clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: "spec.nodeName=" + name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &nodePreview{
+		Name: node.Name,
+		Status: nodeStatus,
+		Unschedulable: node.Spec.Unschedulable,
+		Pods: confirm.Names(pods),
+	}, nil
+}
@@ -0,0 +1,49 @@
+package options
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// IsDryRun reports whether the request asks for a server-side dry run.
+func IsDryRun(request mcp.CallToolRequest) bool {
+	return request.GetBool("dryRun", false)
+}
+
+// DryRun returns the dry-run directive for the request, nil when the change
+// should be persisted.
+func DryRun(request mcp.CallToolRequest) []string {
+	if IsDryRun(request) {
+		return []string{metav1.DryRunAll}
+	}
+	return nil
+}
+
+func Create(request mcp.CallToolRequest) metav1.CreateOptions {
+	return metav1.CreateOptions{DryRun: DryRun(request)}
+}
+
+func Update(request mcp.CallToolRequest) metav1.UpdateOptions {
+	return metav1.UpdateOptions{DryRun: DryRun(request)}
+}
+
+func Delete(request mcp.CallToolRequest) metav1.DeleteOptions {
+	return metav1.DeleteOptions{DryRun: DryRun(request)}
+}
+
+// DryRunResult returns the object the API server would have persisted,
+// including defaulted fields and admission mutations. Managed fields are
+// left out to keep the output short.
+func DryRunResult(object runtime.Object) (*mcp.CallToolResult, error) {
+	if kinds, _, err := scheme.Scheme.ObjectKinds(object); err == nil && len(kinds) > 0 {
+		object.GetObjectKind().SetGroupVersionKind(kinds[0])
+	}
+	if accessor, err := meta.Accessor(object); err == nil {
+		accessor.SetManagedFields(nil)
+	}
+	return result.JSON(result.Change{DryRun: true, Object: object})
+}
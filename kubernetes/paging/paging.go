@@ -0,0 +1,210 @@
+package paging
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+var fallbackNamespaces string
+
+func init() {
+	flag.StringVar(&fallbackNamespaces, "fallbackNamespaces", "", "Comma separated namespaces a list across all namespaces reads when the caller may list neither cluster wide nor the namespaces, defaults to the namespace of the context")
+}
+
+// Options sets the limit and continue arguments of the request on opts. A
+// limit of 0 returns every item.
+func Options(request mcp.CallToolRequest, opts metav1.ListOptions) metav1.ListOptions {
+	opts.Limit = int64(request.GetInt("limit", 0))
+	opts.Continue = request.GetString("continue", "")
+	return opts
+}
+
+// ListFunc lists one page of a namespace and converts the items to the tool
+// output.
+type ListFunc[T any] func(namespace string, opts metav1.ListOptions) ([]T, metav1.ListInterface, error)
+
+// token is the position of a list spanning every namespace. Namespace is
+// empty while the list runs as one call across all namespaces, Continue is
+// then the continue token of that call. Otherwise the list runs one
+// namespace at a time and Namespace is the one to resume in.
+type token struct {
+	Namespace string `json:"namespace,omitempty"`
+	Continue  string `json:"continue,omitempty"`
+}
+
+// AllNamespaces lists the items of every namespace with a single call across
+// all namespaces. When the caller is not allowed to list them cluster wide,
+// it falls back to listing the namespaces one at a time in name order,
+// skipping the namespaces the caller cannot read and reporting them in the
+// result. When the caller may not list the namespaces either, only the
+// --fallbackNamespaces, or the namespace of the context, are read and the
+// result reports them. With a limit the continue token returned records where the next
+// page starts, in the fallback a page can span several namespaces.
+func AllNamespaces[T any](ctx context.Context, request mcp.CallToolRequest, clientset kubernetes.Interface, kind string, opts metav1.ListOptions, list ListFunc[T]) (*mcp.CallToolResult, error) {
+	opts = Options(request, opts)
+	start, err := decode(opts.Continue)
+	if err != nil {
+		return result.Invalid("Provide the continue token returned by the previous call of this tool")
+	}
+	if start.Namespace == "" {
+		opts.Continue = start.Continue
+		items, listMeta, err := list(metav1.NamespaceAll, opts)
+		if err == nil {
+			return page(items, token{Continue: listMeta.GetContinue()}, listMeta.GetRemainingItemCount(), nil, nil)
+		}
+		if !apierrors.IsForbidden(err) {
+			return result.Error(err, "Error in listing %s in all namespaces", kind)
+		}
+		// The continue token of a cluster wide list is no use to the
+		// fallback, it starts over from the first namespace.
+		start = token{}
+	}
+	return byNamespace(ctx, request, clientset, kind, opts, start, list)
+}
+
+func byNamespace[T any](ctx context.Context, request mcp.CallToolRequest, clientset kubernetes.Interface, kind string, opts metav1.ListOptions, start token, list ListFunc[T]) (*mcp.CallToolResult, error) {
+	limit := opts.Limit
+	all, only, err := namespaceNames(ctx, request, clientset)
+	if err != nil {
+		return result.Error(err, "Error in listing namespace")
+	}
+	if only != nil {
+		log.Printf("paging: listing namespaces is forbidden, listing %s in %s only", kind, strings.Join(only, ", "))
+	}
+	var names []string
+	for _, name := range all {
+		if name >= start.Namespace {
+			names = append(names, name)
+		}
+	}
+
+	var output []T
+	var skipped []string
+	for i, name := range names {
+		opts.Continue = ""
+		if name == start.Namespace {
+			opts.Continue = start.Continue
+		}
+		for {
+			if limit > 0 {
+				opts.Limit = limit - int64(len(output))
+			}
+			items, listMeta, err := list(name, opts)
+			if apierrors.IsForbidden(err) {
+				log.Printf("paging: skipping namespace %s, listing %s is forbidden", name, kind)
+				skipped = append(skipped, name)
+				break
+			}
+			if err != nil {
+				return result.Error(err, "Error in listing %s in %s", kind, name)
+			}
+			output = append(output, items...)
+			if listMeta.GetContinue() == "" {
+				break
+			}
+			if limit > 0 && int64(len(output)) >= limit {
+				return page(output, token{Namespace: name, Continue: listMeta.GetContinue()}, nil, skipped, only)
+			}
+			opts.Continue = listMeta.GetContinue()
+		}
+		if limit > 0 && int64(len(output)) >= limit && i+1 < len(names) {
+			return page(output, token{Namespace: names[i+1]}, nil, skipped, only)
+		}
+	}
+	return page(output, token{}, nil, skipped, only)
+}
+
+// namespaceNames returns the namespaces to list one at a time in name order.
+// When the caller may not list the namespaces, they are the
+// --fallbackNamespaces or the namespace of the context, and are returned as
+// only too.
+func namespaceNames(ctx context.Context, request mcp.CallToolRequest, clientset kubernetes.Interface) ([]string, []string, error) {
+	namespaces, err := clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err == nil {
+		names := make([]string, 0, len(namespaces.Items))
+		for _, namespace := range namespaces.Items {
+			names = append(names, namespace.Name)
+		}
+		sort.Strings(names)
+		return names, nil, nil
+	}
+	if !apierrors.IsForbidden(err) {
+		return nil, nil, err
+	}
+	var names []string
+	for _, name := range strings.Split(fallbackNamespaces, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		cluster, clusterErr := client.GetCluster(ctx, request)
+		if clusterErr != nil {
+			return nil, nil, clusterErr
+		}
+		names = []string{cluster.Namespace}
+	}
+	sort.Strings(names)
+	names = slices.Compact(names)
+	return names, names, nil
+}
+
+// page returns items with the continue token of next, no token is returned
+// once the list is complete.
+func page[T any](items []T, next token, remaining *int64, skipped, only []string) (*mcp.CallToolResult, error) {
+	if items == nil {
+		items = []T{}
+	}
+	output := result.Items[T]{
+		Items:              items,
+		RemainingItemCount: remaining,
+		SkippedNamespaces:  skipped,
+		OnlyNamespaces:     only,
+	}
+	if next != (token{}) {
+		data, err := json.Marshal(next)
+		if err != nil {
+			return result.Error(err, "Error in marshalling")
+		}
+		output.Continue = base64.RawURLEncoding.EncodeToString(data)
+	}
+	return result.JSON(output)
+}
+
+// decode returns the position of continueToken. Tokens this package did not
+// return, like the continue token of a list in one namespace, are refused
+// rather than read as the start of the list.
+func decode(continueToken string) (token, error) {
+	var position token
+	if continueToken == "" {
+		return position, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(continueToken)
+	if err != nil {
+		return position, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&position); err != nil {
+		return token{}, err
+	}
+	if position == (token{}) {
+		return position, errors.New("empty continue token")
+	}
+	return position, nil
+}
@@ -0,0 +1,202 @@
+package paging
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func encode(t *testing.T, position token) string {
+	t.Helper()
+	if position == (token{}) {
+		return ""
+	}
+	data, err := json.Marshal(position)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func TestDecode(t *testing.T) {
+	raw := func(data string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(data))
+	}
+	tests := []struct {
+		token   string
+		want    token
+		wantErr bool
+	}{
+		{"", token{}, false},
+		{raw(`{"continue":"abc"}`), token{Continue: "abc"}, false},
+		{raw(`{"namespace":"b","continue":"1"}`), token{Namespace: "b", Continue: "1"}, false},
+		{raw(`{"namespace":"b"}`), token{Namespace: "b"}, false},
+		{"not a token!", token{}, true},
+		{base64.StdEncoding.EncodeToString([]byte(`{"namespace":"b"}`)), token{}, true},
+		{raw("not json"), token{}, true},
+		{raw(`{}`), token{}, true},
+		{raw(`{"namespace":1}`), token{}, true},
+		// The continue token of a list in one namespace.
+		{raw(`{"v":"meta.k8s.io/v1","rv":1234,"start":"nginx\u0000"}`), token{}, true},
+	}
+	for _, test := range tests {
+		got, err := decode(test.token)
+		if (err != nil) != test.wantErr || got != test.want {
+			t.Errorf("decode(%q) = %+v, %v, want %+v, error %v", test.token, got, err, test.want, test.wantErr)
+		}
+	}
+}
+
+// namespaces are listed one at a time since the cluster wide list is
+// forbidden, each page of a namespace is continued with the index of its
+// next item.
+var namespaces = map[string][]string{
+	"a": {"a/0", "a/1", "a/2"},
+	"b": {"b/0", "b/1"},
+	"c": {"c/0"},
+}
+
+func listNamespace(namespace string, opts metav1.ListOptions) ([]string, metav1.ListInterface, error) {
+	if namespace == metav1.NamespaceAll {
+		return nil, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "items"}, "", errors.New("cluster wide list"))
+	}
+	items := namespaces[namespace]
+	start := 0
+	if opts.Continue != "" {
+		start, _ = strconv.Atoi(opts.Continue)
+	}
+	end := len(items)
+	if opts.Limit > 0 && start+int(opts.Limit) < end {
+		end = start + int(opts.Limit)
+	}
+	listMeta := &metav1.ListMeta{}
+	if end < len(items) {
+		listMeta.Continue = strconv.Itoa(end)
+	}
+	return items[start:end], listMeta, nil
+}
+
+func TestAllNamespacesResume(t *testing.T) {
+	clientset := fake.NewClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "c"}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "a"}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "b"}},
+	)
+	tests := []struct {
+		limit int
+		start token
+		want  []string
+		next  token
+	}{
+		{0, token{}, []string{"a/0", "a/1", "a/2", "b/0", "b/1", "c/0"}, token{}},
+		// The page ends inside a namespace.
+		{2, token{}, []string{"a/0", "a/1"}, token{Namespace: "a", Continue: "2"}},
+		{2, token{Namespace: "a", Continue: "2"}, []string{"a/2", "b/0"}, token{Namespace: "b", Continue: "1"}},
+		{2, token{Namespace: "b", Continue: "1"}, []string{"b/1", "c/0"}, token{}},
+		// The page ends exactly at the end of a namespace.
+		{3, token{}, []string{"a/0", "a/1", "a/2"}, token{Namespace: "b"}},
+		{3, token{Namespace: "b"}, []string{"b/0", "b/1", "c/0"}, token{}},
+		{5, token{}, []string{"a/0", "a/1", "a/2", "b/0", "b/1"}, token{Namespace: "c"}},
+		// The page ends at the end of the last namespace.
+		{1, token{Namespace: "c"}, []string{"c/0"}, token{}},
+		// A cluster wide token restarts from the first namespace.
+		{2, token{Continue: "abc"}, []string{"a/0", "a/1"}, token{Namespace: "a", Continue: "2"}},
+	}
+	for _, test := range tests {
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = map[string]any{"limit": test.limit, "continue": encode(t, test.start)}
+		res, err := AllNamespaces(context.Background(), request, clientset, "items", metav1.ListOptions{}, listNamespace)
+		if err != nil || res.IsError {
+			t.Errorf("AllNamespaces(limit %d, %+v) failed: %v %v", test.limit, test.start, err, res.Content)
+			continue
+		}
+		output := res.StructuredContent.(result.Items[string])
+		next, err := decode(output.Continue)
+		if err != nil {
+			t.Errorf("AllNamespaces(limit %d, %+v) returned continue %q: %v", test.limit, test.start, output.Continue, err)
+		}
+		if !slices.Equal(output.Items, test.want) || next != test.next {
+			t.Errorf("AllNamespaces(limit %d, %+v) = %q, %+v, want %q, %+v", test.limit, test.start, output.Items, next, test.want, test.next)
+		}
+	}
+}
+
+func TestAllNamespacesInvalidToken(t *testing.T) {
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"limit": 2, "continue": "bm90IGEgdG9rZW4"}
+	res, err := AllNamespaces(context.Background(), request, fake.NewClientset(), "items", metav1.ListOptions{}, listNamespace)
+	if err != nil || !res.IsError {
+		t.Errorf("AllNamespaces with a foreign continue token = %v, %v, want a failed result", res, err)
+	}
+}
+
+func TestAllNamespacesForbidden(t *testing.T) {
+	defer func(namespaces string) { fallbackNamespaces = namespaces }(fallbackNamespaces)
+	tests := []struct {
+		forbidNamespaces bool
+		fallback         string
+		want             []string
+		skipped, only    []string
+	}{
+		{false, "", []string{"a/web", "b/web"}, []string{"secret"}, nil},
+		{true, "b, secret,b", []string{"b/web"}, []string{"secret"}, []string{"b", "secret"}},
+		{true, "a", []string{"a/web"}, nil, []string{"a"}},
+	}
+	for _, test := range tests {
+		clientset := fake.NewClientset(
+			&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "secret"}},
+			&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "b"}},
+			&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "a"}},
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "a", Name: "web"}},
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "b", Name: "web"}},
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "secret", Name: "web"}},
+		)
+		clientset.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if namespace := action.GetNamespace(); namespace == metav1.NamespaceAll || namespace == "secret" {
+				return true, nil, apierrors.NewForbidden(v1.Resource("pods"), "", errors.New("not allowed"))
+			}
+			return false, nil, nil
+		})
+		if test.forbidNamespaces {
+			clientset.PrependReactor("list", "namespaces", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, apierrors.NewForbidden(v1.Resource("namespaces"), "", errors.New("not allowed"))
+			})
+		}
+		fallbackNamespaces = test.fallback
+		list := func(namespace string, opts metav1.ListOptions) ([]string, metav1.ListInterface, error) {
+			pods, err := clientset.CoreV1().Pods(namespace).List(context.Background(), opts)
+			if err != nil {
+				return nil, nil, err
+			}
+			var items []string
+			for _, pod := range pods.Items {
+				items = append(items, pod.Namespace+"/"+pod.Name)
+			}
+			return items, pods, nil
+		}
+		res, err := AllNamespaces(context.Background(), mcp.CallToolRequest{}, clientset, "pods", metav1.ListOptions{}, list)
+		if err != nil || res.IsError {
+			t.Errorf("AllNamespaces(%v, %q) failed: %v %v", test.forbidNamespaces, test.fallback, err, res.Content)
+			continue
+		}
+		output := res.StructuredContent.(result.Items[string])
+		if !slices.Equal(output.Items, test.want) || !slices.Equal(output.SkippedNamespaces, test.skipped) || !slices.Equal(output.OnlyNamespaces, test.only) {
+			t.Errorf("AllNamespaces(%v, %q) = %q, skipped %q, only %q, want %q, skipped %q, only %q",
+				test.forbidNamespaces, test.fallback, output.Items, output.SkippedNamespaces, output.OnlyNamespaces, test.want, test.skipped, test.only)
+		}
+	}
+}
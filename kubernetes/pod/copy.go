@@ -0,0 +1,218 @@
+package pod
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+)
+
+const (
+	// defaultCopyBytes and maxCopyBytes bound the bytes of file content
+	// copy-from-pod returns, maxCopyBytes also bounds what copy-to-pod
+	// writes.
+	defaultCopyBytes = 1024 * 1024
+	maxCopyBytes     = 10 * 1024 * 1024
+	// copyStderrBytes is the stderr of tar kept to explain a failed copy.
+	copyStderrBytes = 4 * 1024
+)
+
+// errDirectory is returned by copyFrom when the path is a directory and
+// recursive is not set.
+var errDirectory = errors.New("path is a directory, only copy paths ending with /** allow directories")
+
+// copyFromData is the result of copy-from-pod. Truncated is set when the
+// files did not fit in maxBytes, the last file is then partial and the files
+// after it are left out.
+type copyFromData struct {
+	Container string     `json:"container"`
+	Path      string     `json:"path"`
+	Files     []fileData `json:"files"`
+	Truncated bool       `json:"truncated,omitempty"`
+}
+
+// fileData is a file read from a container. Encoding is text when the
+// content looks like text and base64 was not asked for, base64 otherwise. Target
+// is set instead of Content for symbolic links.
+type fileData struct {
+	Path      string `json:"path"`
+	Size      int64  `json:"size"`
+	Mode      string `json:"mode"`
+	Encoding  string `json:"encoding,omitempty"`
+	Content   string `json:"content,omitempty"`
+	Target    string `json:"target,omitempty"`
+	Truncated bool   `json:"truncated,omitempty"`
+}
+
+// copyFrom reads the file or directory src out of container by streaming
+// "tar cf -" from it, like kubectl cp. src must be a real path, see realPath.
+// A directory is only read when recursive is set, errDirectory is returned
+// otherwise. At most limit bytes of file content are read. encoding is
+// "text", "base64" or empty to pick per file.
+func copyFrom(ctx context.Context, cluster *client.Cluster, namespace, name, container, src string, recursive bool, limit int, encoding string) (copyFromData, error) {
+	output := copyFromData{Container: container, Path: src, Files: []fileData{}}
+	dir, base := path.Split(src)
+	if base == "" {
+		dir, base = "/", "."
+	}
+	executor, err := newExecutor(cluster, namespace, name, container, []string{"tar", "cf", "-", "-C", dir, base}, false)
+	if err != nil {
+		return output, err
+	}
+	// The stream is cancelled when the limit is reached or the archive cannot
+	// be read, the rest of it is not read.
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	reader, writer := io.Pipe()
+	stderr := &limitedBuffer{limit: copyStderrBytes}
+	done := make(chan error, 1)
+	go func() {
+		err := executor.StreamWithContext(streamCtx, remotecommand.StreamOptions{Stdout: writer, Stderr: stderr})
+		writer.CloseWithError(err)
+		done <- err
+	}()
+	remaining := int64(limit)
+	archive := tar.NewReader(reader)
+	var readErr error
+	for {
+		header, err := archive.Next()
+		if err != nil {
+			if err != io.EOF {
+				readErr = err
+			}
+			break
+		}
+		file := fileData{
+			Path: path.Join(dir, header.Name),
+			Size: header.Size,
+			Mode: header.FileInfo().Mode().String(),
+		}
+		if header.Typeflag == tar.TypeDir && !recursive {
+			readErr = errDirectory
+			break
+		}
+		switch header.Typeflag {
+		case tar.TypeReg:
+		case tar.TypeSymlink:
+			file.Target = header.Linkname
+			output.Files = append(output.Files, file)
+			continue
+		default:
+			continue
+		}
+		content, err := io.ReadAll(io.LimitReader(archive, remaining))
+		if err != nil {
+			readErr = err
+			break
+		}
+		remaining -= int64(len(content))
+		file.Truncated = int64(len(content)) < header.Size
+		file.Encoding, file.Content = encode(content, encoding)
+		output.Files = append(output.Files, file)
+		if file.Truncated {
+			output.Truncated = true
+			break
+		}
+	}
+	if output.Truncated || readErr != nil {
+		cancel()
+	}
+	// Drain what is left, like the end of archive blocks, until the stream
+	// ends and closes the pipe.
+	io.Copy(io.Discard, reader)
+	err = <-done
+	if output.Truncated {
+		return output, nil
+	}
+	if errors.Is(readErr, errDirectory) {
+		return output, readErr
+	}
+	if code, exited := exitCode(err); !exited || code != 0 {
+		return output, copyError(err, stderr)
+	}
+	return output, readErr
+}
+
+// realPath resolves the symbolic links of the absolute path name in
+// container with "readlink -f", so the copy paths of the server are checked
+// against the file a copy actually reaches. The last element of name does
+// not have to exist.
+func realPath(ctx context.Context, cluster *client.Cluster, namespace, name, container, file string) (string, error) {
+	output, err := execCommand(ctx, cluster, namespace, name, container, []string{"readlink", "-f", file}, copyStderrBytes)
+	if err != nil {
+		return "", err
+	}
+	if output.ExitCode == nil || *output.ExitCode != 0 {
+		return "", fmt.Errorf("resolving %s: %s", file, strings.TrimSpace(output.Stderr))
+	}
+	resolved := strings.TrimSpace(output.Stdout)
+	if !path.IsAbs(resolved) {
+		return "", fmt.Errorf("resolving %s: readlink returned %q", file, resolved)
+	}
+	return path.Clean(resolved), nil
+}
+
+// copyTo writes content to the file dst in container by streaming a tar
+// archive of it to "tar xf -", like kubectl cp. dst must be a real path, see
+// realPath. The file is created with
+// mode 0644, or replaced when it exists.
+func copyTo(ctx context.Context, cluster *client.Cluster, namespace, name, container, dst string, content []byte) error {
+	dir, base := path.Split(dst)
+	var archive bytes.Buffer
+	writer := tar.NewWriter(&archive)
+	err := writer.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     base,
+		Mode:     0o644,
+		Size:     int64(len(content)),
+		ModTime:  time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(content); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	executor, err := newExecutor(cluster, namespace, name, container, []string{"tar", "xmf", "-", "-C", dir}, true)
+	if err != nil {
+		return err
+	}
+	stderr := &limitedBuffer{limit: copyStderrBytes}
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdin: &archive, Stdout: io.Discard, Stderr: stderr})
+	if code, exited := exitCode(err); !exited || code != 0 {
+		return copyError(err, stderr)
+	}
+	return nil
+}
+
+// copyError explains a failed tar with its stderr, like "No such file or
+// directory" or a missing tar binary.
+func copyError(err error, stderr *limitedBuffer) error {
+	if message := strings.TrimSpace(stderr.String()); message != "" {
+		return fmt.Errorf("%w: %s", err, message)
+	}
+	return err
+}
+
+// encode returns content as text when it is valid UTF-8 without NUL bytes
+// and encoding is not base64, as base64 otherwise.
+func encode(content []byte, encoding string) (string, string) {
+	if encoding != "base64" && utf8.Valid(content) && bytes.IndexByte(content, 0) < 0 {
+		return "text", string(content)
+	}
+	return "base64", base64.StdEncoding.EncodeToString(content)
+}
@@ -0,0 +1,175 @@
+package pod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+)
+
+// debugPollInterval is how often debug-pod checks the debug container.
+const debugPollInterval = time.Second
+
+// debugData is the result of debug-pod. State is the state of the debug
+// container when the call returned: running, terminated or waiting. With a
+// command, Output is its log once it terminated. TimedOut is set when the
+// call ran out of time first, the container keeps running and its log can be
+// read with pod-log.
+type debugData struct {
+	Container       string   `json:"container"`
+	Image           string   `json:"image"`
+	Target          string   `json:"target,omitempty"`
+	Command         []string `json:"command,omitempty"`
+	State           string   `json:"state"`
+	Reason          string   `json:"reason,omitempty"`
+	ExitCode        *int     `json:"exitCode,omitempty"`
+	Output          string   `json:"output,omitempty"`
+	OutputTruncated bool     `json:"outputTruncated,omitempty"`
+	TimedOut        bool     `json:"timedOut,omitempty"`
+}
+
+// debugContainer adds an ephemeral container running image to the pod name,
+// like kubectl debug. target is the container whose process namespace it
+// joins. It defaults to the only container of the pod or its
+// kubectl.kubernetes.io/default-container annotation, and to none when the
+// pod has several containers without one. Without a command the container
+// keeps its stdin open so the image's shell stays up for exec-pod. With a
+// command the container runs it, and its log is returned once it terminates.
+func debugContainer(ctx context.Context, cluster *client.Cluster, namespace, name, container, image, target string, command []string, limit int) (debugData, error) {
+	output := debugData{Container: container, Image: image, Target: target, Command: command}
+	pods := cluster.Clientset.CoreV1().Pods(namespace)
+	pod, err := pods.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return output, err
+	}
+	if pod.Status.Phase != v1.PodRunning {
+		return output, fmt.Errorf("pod %s/%s is %s, not Running", namespace, name, pod.Status.Phase)
+	}
+	if output.Target == "" {
+		output.Target, _ = defaultContainer(pod)
+	}
+	if output.Container == "" {
+		output.Container = debugName(pod)
+	} else if hasContainer(pod, output.Container) {
+		return output, fmt.Errorf("pod %s/%s already has a container named %s", namespace, name, output.Container)
+	}
+	ephemeral := v1.EphemeralContainer{
+		EphemeralContainerCommon: v1.EphemeralContainerCommon{
+			Name:                     output.Container,
+			Image:                    image,
+			Command:                  command,
+			Stdin:                    len(command) == 0,
+			TTY:                      len(command) == 0,
+			TerminationMessagePolicy: v1.TerminationMessageFallbackToLogsOnError,
+		},
+		TargetContainerName: output.Target,
+	}
+	updated := pod.DeepCopy()
+	updated.Spec.EphemeralContainers = append(updated.Spec.EphemeralContainers, ephemeral)
+	if _, err := pods.UpdateEphemeralContainers(ctx, name, updated, metav1.UpdateOptions{}); err != nil {
+		return output, err
+	}
+
+	var state v1.ContainerState
+	err = wait.PollUntilContextCancel(ctx, debugPollInterval, false, func(ctx context.Context) (bool, error) {
+		pod, err := pods.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		for _, status := range pod.Status.EphemeralContainerStatuses {
+			if status.Name != output.Container {
+				continue
+			}
+			state = status.State
+			if state.Waiting != nil && failedWaiting(state.Waiting.Reason) {
+				return false, fmt.Errorf("debug container %s is %s: %s", output.Container, state.Waiting.Reason, state.Waiting.Message)
+			}
+			return state.Terminated != nil || (state.Running != nil && len(command) == 0), nil
+		}
+		return false, nil
+	})
+	output.State, output.Reason = stateName(state)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			output.TimedOut = true
+			return output, nil
+		}
+		return output, err
+	}
+	if state.Terminated == nil {
+		return output, nil
+	}
+	code := int(state.Terminated.ExitCode)
+	output.ExitCode = &code
+	limitBytes := int64(limit) + 1
+	log, err := readLog(ctx, cluster.Clientset, namespace, name, v1.PodLogOptions{Container: output.Container, LimitBytes: &limitBytes})
+	if err != nil {
+		return output, fmt.Errorf("reading the output of debug container %s: %w", output.Container, err)
+	}
+	if len(log) > limit {
+		log, output.OutputTruncated = log[:limit], true
+	}
+	output.Output = log
+	return output, nil
+}
+
+// debugName returns a container name that is not used in pod yet, like
+// debugger-x7k2q.
+func debugName(pod *v1.Pod) string {
+	for {
+		name := "debugger-" + utilrand.String(5)
+		if !hasContainer(pod, name) {
+			return name
+		}
+	}
+}
+
+func hasContainer(pod *v1.Pod, name string) bool {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == name {
+			return true
+		}
+	}
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name == name {
+			return true
+		}
+	}
+	for _, container := range pod.Spec.EphemeralContainers {
+		if container.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// failedWaiting reports whether a container waiting for reason will not
+// start without a change, like an image that cannot be pulled.
+func failedWaiting(reason string) bool {
+	switch reason {
+	case "ErrImagePull", "ImagePullBackOff", "InvalidImageName", "ErrImageNeverPull",
+		"CreateContainerConfigError", "CreateContainerError", "RunContainerError":
+		return true
+	}
+	return false
+}
+
+// stateName returns the state of a container and the reason given for it.
+func stateName(state v1.ContainerState) (string, string) {
+	switch {
+	case state.Running != nil:
+		return "running", ""
+	case state.Terminated != nil:
+		return "terminated", state.Terminated.Reason
+	case state.Waiting != nil:
+		return "waiting", state.Waiting.Reason
+	}
+	return "waiting", ""
+}
@@ -0,0 +1,275 @@
+package pod
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+)
+
+// maxEvents is the number of most recent events returned with a pod.
+const maxEvents = 20
+
+// podDetail is the describe-style output of get-pod.
+type podDetail struct {
+	podData
+	Node           string          `json:"node,omitempty"`
+	PodIPs         []string        `json:"podIPs,omitempty"`
+	HostIP         string          `json:"hostIP,omitempty"`
+	QOSClass       string          `json:"qosClass,omitempty"`
+	ServiceAccount string          `json:"serviceAccount,omitempty"`
+	StatusReason   string          `json:"statusReason,omitempty"`
+	StatusMessage  string          `json:"statusMessage,omitempty"`
+	Conditions     []conditionData `json:"conditions,omitempty"`
+	InitContainers []containerData `json:"initContainers,omitempty"`
+	Containers     []containerData `json:"containers,omitempty"`
+	Volumes        []volumeData    `json:"volumes,omitempty"`
+	Owners         []ownerData     `json:"owners,omitempty"`
+	Events         []eventData     `json:"events,omitempty"`
+	EventsError    string          `json:"eventsError,omitempty"`
+}
+
+type conditionData struct {
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	Reason             string `json:"reason,omitempty"`
+	Message            string `json:"message,omitempty"`
+	LastTransitionTime string `json:"lastTransitionTime,omitempty"`
+}
+
+// containerData is the spec and status of one container. State is Waiting,
+// Running or Terminated, Reason and Message explain it. The Last fields
+// describe the previous run when the container restarted.
+type containerData struct {
+	Name                  string            `json:"name"`
+	Image                 string            `json:"image,omitempty"`
+	Ready                 bool              `json:"ready"`
+	RestartCount          int32             `json:"restartCount"`
+	State                 string            `json:"state,omitempty"`
+	Reason                string            `json:"reason,omitempty"`
+	Message               string            `json:"message,omitempty"`
+	StartedAt             string            `json:"startedAt,omitempty"`
+	ExitCode              *int32            `json:"exitCode,omitempty"`
+	LastTerminationReason string            `json:"lastTerminationReason,omitempty"`
+	LastExitCode          *int32            `json:"lastExitCode,omitempty"`
+	LastFinishedAt        string            `json:"lastFinishedAt,omitempty"`
+	Requests              map[string]string `json:"requests,omitempty"`
+	Limits                map[string]string `json:"limits,omitempty"`
+}
+
+// volumeData names a volume, its type like ConfigMap or
+// PersistentVolumeClaim, and the object it comes from when there is one.
+type volumeData struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Source string `json:"source,omitempty"`
+}
+
+type ownerData struct {
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Controller bool   `json:"controller,omitempty"`
+}
+
+type eventData struct {
+	Type     string `json:"type,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+	Message  string `json:"message,omitempty"`
+	Count    int32  `json:"count,omitempty"`
+	LastSeen string `json:"lastSeen,omitempty"`
+	Source   string `json:"source,omitempty"`
+}
+
+// describe returns the details of pod with its most recent events. Failing
+// to read the events does not fail the call, the error is returned in
+// EventsError.
+func describe(ctx context.Context, clientset kubernetes.Interface, pod *v1.Pod, summary podData) podDetail {
+	detail := podDetail{
+		podData:        summary,
+		Node:           pod.Spec.NodeName,
+		HostIP:         pod.Status.HostIP,
+		QOSClass:       string(pod.Status.QOSClass),
+		ServiceAccount: pod.Spec.ServiceAccountName,
+		StatusReason:   pod.Status.Reason,
+		StatusMessage:  pod.Status.Message,
+		InitContainers: containers(pod.Spec.InitContainers, pod.Status.InitContainerStatuses),
+		Containers:     containers(pod.Spec.Containers, pod.Status.ContainerStatuses),
+	}
+	if pod.DeletionTimestamp != nil {
+		detail.Status = "Terminating"
+	}
+	for _, ip := range pod.Status.PodIPs {
+		detail.PodIPs = append(detail.PodIPs, ip.IP)
+	}
+	for _, condition := range pod.Status.Conditions {
+		detail.Conditions = append(detail.Conditions, conditionData{
+			Type:               string(condition.Type),
+			Status:             string(condition.Status),
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			LastTransitionTime: timestamp(condition.LastTransitionTime),
+		})
+	}
+	for _, volume := range pod.Spec.Volumes {
+		detail.Volumes = append(detail.Volumes, describeVolume(volume))
+	}
+	for _, owner := range pod.OwnerReferences {
+		detail.Owners = append(detail.Owners, ownerData{
+			Kind:       owner.Kind,
+			Name:       owner.Name,
+			Controller: owner.Controller != nil && *owner.Controller,
+		})
+	}
+	events, err := podEvents(ctx, clientset, pod)
+	if err != nil {
+		detail.EventsError = err.Error()
+	}
+	detail.Events = events
+	return detail
+}
+
+func containers(specs []v1.Container, statuses []v1.ContainerStatus) []containerData {
+	var output []containerData
+	for _, spec := range specs {
+		container := containerData{
+			Name:     spec.Name,
+			Image:    spec.Image,
+			Requests: quantities(spec.Resources.Requests),
+			Limits:   quantities(spec.Resources.Limits),
+		}
+		for _, status := range statuses {
+			if status.Name != spec.Name {
+				continue
+			}
+			container.Ready = status.Ready
+			container.RestartCount = status.RestartCount
+			switch state := status.State; {
+			case state.Running != nil:
+				container.State = "Running"
+				container.StartedAt = timestamp(state.Running.StartedAt)
+			case state.Waiting != nil:
+				container.State = "Waiting"
+				container.Reason = state.Waiting.Reason
+				container.Message = state.Waiting.Message
+			case state.Terminated != nil:
+				container.State = "Terminated"
+				container.Reason = state.Terminated.Reason
+				container.Message = state.Terminated.Message
+				container.StartedAt = timestamp(state.Terminated.StartedAt)
+				container.ExitCode = &state.Terminated.ExitCode
+			}
+			if last := status.LastTerminationState.Terminated; last != nil {
+				container.LastTerminationReason = last.Reason
+				container.LastExitCode = &last.ExitCode
+				container.LastFinishedAt = timestamp(last.FinishedAt)
+			}
+		}
+		output = append(output, container)
+	}
+	return output
+}
+
+func quantities(resources v1.ResourceList) map[string]string {
+	if len(resources) == 0 {
+		return nil
+	}
+	output := make(map[string]string, len(resources))
+	for name, quantity := range resources {
+		output[string(name)] = quantity.String()
+	}
+	return output
+}
+
+func describeVolume(volume v1.Volume) volumeData {
+	output := volumeData{Name: volume.Name}
+	source := volume.VolumeSource
+	switch {
+	case source.ConfigMap != nil:
+		output.Type, output.Source = "ConfigMap", source.ConfigMap.Name
+	case source.Secret != nil:
+		output.Type, output.Source = "Secret", source.Secret.SecretName
+	case source.PersistentVolumeClaim != nil:
+		output.Type, output.Source = "PersistentVolumeClaim", source.PersistentVolumeClaim.ClaimName
+	case source.EmptyDir != nil:
+		output.Type = "EmptyDir"
+	case source.HostPath != nil:
+		output.Type, output.Source = "HostPath", source.HostPath.Path
+	case source.Projected != nil:
+		output.Type = "Projected"
+	case source.DownwardAPI != nil:
+		output.Type = "DownwardAPI"
+	case source.CSI != nil:
+		output.Type, output.Source = "CSI", source.CSI.Driver
+	case source.Ephemeral != nil:
+		output.Type = "Ephemeral"
+	case source.NFS != nil:
+		output.Type, output.Source = "NFS", source.NFS.Server+":"+source.NFS.Path
+	default:
+		output.Type = "Other"
+	}
+	return output
+}
+
+// podEvents returns the maxEvents most recent events of pod, oldest first.
+func podEvents(ctx context.Context, clientset kubernetes.Interface, pod *v1.Pod) ([]eventData, error) {
+	selector := fields.Set{
+		"involvedObject.kind":      "Pod",
+		"involvedObject.name":      pod.Name,
+		"involvedObject.namespace": pod.Namespace,
+		"involvedObject.uid":       string(pod.UID),
+	}.AsSelector().String()
+	events, err := clientset.CoreV1().Events(pod.Namespace).List(ctx, metav1.ListOptions{FieldSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	items := events.Items
+	sort.SliceStable(items, func(i, j int) bool {
+		return lastSeen(items[i]).Before(lastSeen(items[j]))
+	})
+	if len(items) > maxEvents {
+		items = items[len(items)-maxEvents:]
+	}
+	var output []eventData
+	for _, event := range items {
+		source := event.Source.Component
+		if source == "" {
+			source = event.ReportingController
+		}
+		output = append(output, eventData{
+			Type:     event.Type,
+			Reason:   event.Reason,
+			Message:  event.Message,
+			Count:    event.Count,
+			LastSeen: lastSeen(event).UTC().Format(time.RFC3339),
+			Source:   source,
+		})
+	}
+	return output, nil
+}
+
+// lastSeen is the last time event happened, the fields set depend on the
+// component that reported it.
+func lastSeen(event v1.Event) time.Time {
+	switch {
+	case event.Series != nil:
+		return event.Series.LastObservedTime.Time
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+func timestamp(t metav1.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,127 @@
+package pod
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+)
+
+const (
+	// defaultExecOutputBytes and maxExecOutputBytes bound the stdout and
+	// stderr kept by exec-pod, each.
+	defaultExecOutputBytes = 64 * 1024
+	maxExecOutputBytes     = 1024 * 1024
+)
+
+// execData is the result of exec-pod. ExitCode is set once the command
+// exited. TimedOut is set when the call ran out of time first, the output
+// is then what the command wrote until then.
+type execData struct {
+	Container       string   `json:"container"`
+	Command         []string `json:"command"`
+	Stdout          string   `json:"stdout"`
+	Stderr          string   `json:"stderr"`
+	ExitCode        *int     `json:"exitCode,omitempty"`
+	StdoutTruncated bool     `json:"stdoutTruncated,omitempty"`
+	StderrTruncated bool     `json:"stderrTruncated,omitempty"`
+	TimedOut        bool     `json:"timedOut,omitempty"`
+}
+
+// limitedBuffer keeps the first limit bytes written to it and discards the
+// rest, so a chatty command does not block the stream. The buffer is not
+// embedded so io.Copy cannot bypass the limit through ReadFrom.
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.buf.Len(); room < len(p) {
+		b.truncated = true
+		if room > 0 {
+			b.buf.Write(p[:room])
+		}
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) String() string {
+	return b.buf.String()
+}
+
+// execCommand runs command in container through the pods/exec subresource.
+// A command that exits with a non-zero code is not an error, the code is
+// returned in ExitCode.
+func execCommand(ctx context.Context, cluster *client.Cluster, namespace, name, container string, command []string, limit int) (execData, error) {
+	output := execData{Container: container, Command: command}
+	executor, err := newExecutor(cluster, namespace, name, container, command, false)
+	if err != nil {
+		return output, err
+	}
+	stdout := &limitedBuffer{limit: limit}
+	stderr := &limitedBuffer{limit: limit}
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: stdout, Stderr: stderr})
+	output.Stdout, output.StdoutTruncated = stdout.String(), stdout.truncated
+	output.Stderr, output.StderrTruncated = stderr.String(), stderr.truncated
+	switch code, exited := exitCode(err); {
+	case exited:
+		output.ExitCode = &code
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		output.TimedOut = true
+	default:
+		return output, err
+	}
+	return output, nil
+}
+
+// newExecutor returns the executor of command in container, over WebSocket
+// with a fallback to SPDY for older API servers. stdin is set when the
+// command reads its standard input.
+func newExecutor(cluster *client.Cluster, namespace, name, container string, command []string, stdin bool) (remotecommand.Executor, error) {
+	req := cluster.Clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(name).
+		SubResource("exec").
+		VersionedParams(&v1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdin:     stdin,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+	spdy, err := remotecommand.NewSPDYExecutor(cluster.Config, "POST", req.URL())
+	if err != nil {
+		return nil, err
+	}
+	websocket, err := remotecommand.NewWebSocketExecutor(cluster.Config, "GET", req.URL().String())
+	if err != nil {
+		return nil, err
+	}
+	return remotecommand.NewFallbackExecutor(websocket, spdy, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+}
+
+// exitCode returns the exit code of a command from the error of its stream.
+// exited is false when the stream failed before the command exited.
+func exitCode(err error) (int, bool) {
+	if err == nil {
+		return 0, true
+	}
+	var exitErr utilexec.ExitError
+	if errors.As(err, &exitErr) && exitErr.Exited() {
+		return exitErr.ExitStatus(), true
+	}
+	return 0, false
+}
@@ -0,0 +1,232 @@
+package pod
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/progress"
+	"github.com/naveenthangaraj03/k8s-mcp-server/timeout"
+)
+
+// defaultContainerAnnotation names the container kubectl picks when a pod
+// has several and none is given.
+const defaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
+const (
+	// defaultFollowSeconds and maxFollowSeconds bound how long pod-log keeps
+	// following a log.
+	defaultFollowSeconds = timeout.DefaultFollowSeconds
+	maxFollowSeconds     = 600
+	// followMaxLines is the number of most recent followed lines returned in
+	// the result, every line is sent as a notification.
+	followMaxLines = 1000
+)
+
+// logOptions reads the arguments shared by the log tools. The message is
+// set when an argument is invalid.
+func logOptions(request mcp.CallToolRequest) (v1.PodLogOptions, string) {
+	opts := v1.PodLogOptions{
+		Previous:   request.GetBool("previous", false),
+		Timestamps: request.GetBool("timestamps", false),
+	}
+	if tail := int64(request.GetInt("tailLine", 100)); tail > 0 {
+		opts.TailLines = &tail
+	}
+	if limit := int64(request.GetInt("limitBytes", 0)); limit > 0 {
+		opts.LimitBytes = &limit
+	}
+	seconds := int64(request.GetInt("sinceSeconds", 0))
+	sinceTime := request.GetString("sinceTime", "")
+	if seconds > 0 && sinceTime != "" {
+		return opts, "Provide either sinceSeconds or sinceTime, not both"
+	}
+	if seconds > 0 {
+		opts.SinceSeconds = &seconds
+	}
+	if sinceTime != "" {
+		since, err := time.Parse(time.RFC3339, sinceTime)
+		if err != nil {
+			return opts, "Provide sinceTime as an RFC3339 time. Ex: 2026-10-18T10:00:00Z"
+		}
+		opts.SinceTime = &metav1.Time{Time: since}
+	}
+	return opts, ""
+}
+
+// defaultContainer returns the container to read the logs of when none is
+// given: the only container of the pod, or the one named by the
+// kubectl.kubernetes.io/default-container annotation.
+func defaultContainer(pod *v1.Pod) (string, error) {
+	if len(pod.Spec.Containers) == 1 {
+		return pod.Spec.Containers[0].Name, nil
+	}
+	if name := pod.Annotations[defaultContainerAnnotation]; name != "" {
+		return name, nil
+	}
+	names := make([]string, 0, len(pod.Spec.Containers))
+	for _, container := range pod.Spec.Containers {
+		names = append(names, container.Name)
+	}
+	return "", fmt.Errorf("Provide containerName, pod %s/%s has the containers %s", pod.Namespace, pod.Name, strings.Join(names, ", "))
+}
+
+// readLog returns the log of one container.
+func readLog(ctx context.Context, clientset kubernetes.Interface, namespace, name string, opts v1.PodLogOptions) (string, error) {
+	stream, err := clientset.CoreV1().Pods(namespace).GetLogs(name, &opts).Stream(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer stream.Close()
+	body, err := io.ReadAll(stream)
+	return string(body), err
+}
+
+// followLog streams the log of one container for seconds, sending each line
+// to the client with notifier as it is written. It stops early when the
+// container exits or the call ends. The result holds the last followMaxLines
+// lines, with Truncated set when older ones were dropped, and why it stopped:
+// ended when the container exited, duration when seconds elapsed, timeout or
+// cancelled when the call ran out of time or was cancelled.
+func followLog(ctx context.Context, clientset kubernetes.Interface, namespace, name string, opts v1.PodLogOptions, seconds int, notifier *progress.Notifier) (podLogData, error) {
+	output := podLogData{Container: opts.Container}
+	followCtx, cancel := context.WithTimeout(ctx, time.Duration(seconds)*time.Second)
+	defer cancel()
+	opts.Follow = true
+	stream, err := clientset.CoreV1().Pods(namespace).GetLogs(name, &opts).Stream(followCtx)
+	if err != nil {
+		return output, err
+	}
+	defer stream.Close()
+	var lines []string
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		notifier.Send(ctx, line)
+		lines = append(lines, line)
+		if len(lines) > followMaxLines {
+			lines, output.Truncated = lines[1:], true
+		}
+	}
+	if len(lines) > 0 {
+		output.Log = strings.Join(lines, "\n") + "\n"
+	}
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		output.Stopped = "timeout"
+	case ctx.Err() != nil:
+		output.Stopped = "cancelled"
+	case followCtx.Err() != nil:
+		output.Stopped = "duration"
+	default:
+		output.Stopped = "ended"
+		return output, scanner.Err()
+	}
+	return output, nil
+}
+
+// logLine is a line of a container log with the time the container wrote
+// it.
+type logLine struct {
+	time      time.Time
+	timestamp string
+	pod       string
+	container string
+	text      string
+}
+
+// allContainerLogs reads the logs of every init, regular and ephemeral
+// container of pod and interleaves their lines in time order, each prefixed
+// with its container name. Containers whose log cannot be read, like init
+// containers that did not run, are returned in errors and skipped.
+func allContainerLogs(ctx context.Context, clientset kubernetes.Interface, pod *v1.Pod, opts v1.PodLogOptions) (string, map[string]string) {
+	var names []string
+	for _, container := range pod.Spec.InitContainers {
+		names = append(names, container.Name)
+	}
+	for _, container := range pod.Spec.Containers {
+		names = append(names, container.Name)
+	}
+	for _, container := range pod.Spec.EphemeralContainers {
+		names = append(names, container.Name)
+	}
+	lines, errors := containerLogs(ctx, clientset, pod, names, opts)
+	sortLines(lines)
+	var output strings.Builder
+	for _, line := range lines {
+		output.WriteString(formatLine("["+line.container+"] ", line, opts.Timestamps))
+	}
+	return output.String(), errors
+}
+
+// containerLogs reads the logs of the named containers of pod. The logs are
+// read with timestamps so they can be ordered, formatLine only prints them
+// when opts asks for them. errors holds the containers whose log could not
+// be read, nil when every log was read.
+func containerLogs(ctx context.Context, clientset kubernetes.Interface, pod *v1.Pod, names []string, opts v1.PodLogOptions) ([]logLine, map[string]string) {
+	opts.Timestamps = true
+	var lines []logLine
+	var errors map[string]string
+	for _, name := range names {
+		opts.Container = name
+		log, err := readLog(ctx, clientset, pod.Namespace, pod.Name, opts)
+		if err != nil {
+			if errors == nil {
+				errors = map[string]string{}
+			}
+			errors[name] = err.Error()
+			continue
+		}
+		for _, line := range splitLog(name, log) {
+			line.pod = pod.Name
+			lines = append(lines, line)
+		}
+	}
+	return lines, errors
+}
+
+// sortLines orders lines by the time they were written, keeping the order of
+// each log for lines written at the same time.
+func sortLines(lines []logLine) {
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].time.Before(lines[j].time)
+	})
+}
+
+func formatLine(prefix string, line logLine, timestamps bool) string {
+	if timestamps && line.timestamp != "" {
+		prefix += line.timestamp + " "
+	}
+	return prefix + line.text + "\n"
+}
+
+// splitLog splits a log read with timestamps into lines. A line without a
+// timestamp keeps the time of the line before it.
+func splitLog(container, log string) []logLine {
+	var lines []logLine
+	var last time.Time
+	scanner := bufio.NewScanner(strings.NewReader(log))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := logLine{container: container, text: scanner.Text(), time: last}
+		// An empty line is written as the timestamp alone.
+		timestamp, text, _ := strings.Cut(line.text, " ")
+		if t, err := time.Parse(time.RFC3339Nano, timestamp); err == nil {
+			line.time, line.timestamp, line.text = t, timestamp, text
+			last = t
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
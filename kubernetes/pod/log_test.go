@@ -0,0 +1,79 @@
+package pod
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitLog(t *testing.T) {
+	tests := []struct {
+		log  string
+		want string
+	}{
+		{"", ""},
+		{"2026-10-18T10:00:00.5Z started\n2026-10-18T10:00:01Z ready\n",
+			"10:00:00.5 2026-10-18T10:00:00.5Z started|10:00:01 2026-10-18T10:00:01Z ready|"},
+		// A line without a timestamp keeps the time of the line before it.
+		{"2026-10-18T10:00:01Z panic: boom\n\tgoroutine 1\n2026-10-18T10:00:02Z exit\n",
+			"10:00:01 2026-10-18T10:00:01Z panic: boom|10:00:01 \tgoroutine 1|10:00:02 2026-10-18T10:00:02Z exit|"},
+		{"no timestamp\n2026-10-18T10:00:01Z ready\n",
+			"00:00:00 no timestamp|10:00:01 2026-10-18T10:00:01Z ready|"},
+		{"2026-10-18T10:00:01Z\n2026-10-18T10:00:02Z  indented\n",
+			"10:00:01 2026-10-18T10:00:01Z |10:00:02 2026-10-18T10:00:02Z  indented|"},
+		{"10:00:01 not a timestamp\n", "00:00:00 10:00:01 not a timestamp|"},
+	}
+	for _, test := range tests {
+		var got strings.Builder
+		for _, line := range splitLog("app", test.log) {
+			if line.container != "app" {
+				t.Errorf("splitLog(%q) container = %q, want app", test.log, line.container)
+			}
+			got.WriteString(line.time.Format("15:04:05.999") + " " + strings.TrimSuffix(formatLine("", line, true), "\n") + "|")
+		}
+		if got.String() != test.want {
+			t.Errorf("splitLog(%q) = %q, want %q", test.log, got.String(), test.want)
+		}
+	}
+}
+
+func TestSortLines(t *testing.T) {
+	tests := []struct {
+		logs       [][2]string
+		timestamps bool
+		want       string
+	}{
+		{[][2]string{
+			{"init", "2026-10-18T10:00:00Z migrate\n2026-10-18T10:00:02Z done\n"},
+			{"app", "2026-10-18T10:00:01Z start\n2026-10-18T10:00:03Z ready\n"},
+		}, false, "[init] migrate\n[app] start\n[init] done\n[app] ready\n"},
+		{[][2]string{
+			{"init", "2026-10-18T10:00:00Z migrate\n"},
+			{"app", "2026-10-18T10:00:01Z start\n"},
+		}, true, "[init] 2026-10-18T10:00:00Z migrate\n[app] 2026-10-18T10:00:01Z start\n"},
+		// Lines written at the same time keep the container order, lines
+		// without a timestamp stay after the line before them.
+		{[][2]string{
+			{"app", "2026-10-18T10:00:01Z a1\n2026-10-18T10:00:03Z a2\ntrace\n"},
+			{"sidecar", "2026-10-18T10:00:01Z s1\n2026-10-18T10:00:02Z s2\n2026-10-18T10:00:04Z s3\n"},
+		}, false, "[app] a1\n[sidecar] s1\n[sidecar] s2\n[app] a2\n[app] trace\n[sidecar] s3\n"},
+		// Fractional seconds order lines within the same second.
+		{[][2]string{
+			{"app", "2026-10-18T10:00:01.900000000Z late\n"},
+			{"sidecar", "2026-10-18T10:00:01.100000000Z early\n"},
+		}, false, "[sidecar] early\n[app] late\n"},
+	}
+	for _, test := range tests {
+		var lines []logLine
+		for _, log := range test.logs {
+			lines = append(lines, splitLog(log[0], log[1])...)
+		}
+		sortLines(lines)
+		var got strings.Builder
+		for _, line := range lines {
+			got.WriteString(formatLine("["+line.container+"] ", line, test.timestamps))
+		}
+		if got.String() != test.want {
+			t.Errorf("sortLines(%q) = %q, want %q", test.logs, got.String(), test.want)
+		}
+	}
+}
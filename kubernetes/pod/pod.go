@@ -2,15 +2,25 @@ package pod
 
 import (
 	"fmt"
+	"errors"
 	"context"
-	"encoding/json"
-	"io"
 	"strings"
 	"strconv"
+	"slices"
+	"regexp"
+	"path"
+	"encoding/base64"
+	"time"
 	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/paging"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/options"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/view"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/progress"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/api/core/v1"
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 )
 
 type podData struct {
@@ -19,25 +29,72 @@ type podData struct {
 	Status    string            `json:"status,omitempty"`
 	Labels    map[string]string `json:"labels,omitempty"`
 	ContainerName []string      `json:"containerNames,omitempty"`
+	Restarts  int32             `json:"restarts"`
+	CreationTimestamp string    `json:"creationTimestamp,omitempty"`
+}
+
+// podLogData is the log of pod-log. Container is the container it was read
+// from, Errors the containers whose log could not be read in allContainers
+// mode. Stopped is why a followed log stopped: ended, duration, timeout or
+// cancelled. Truncated is set when a followed log dropped its oldest lines.
+type podLogData struct {
+	Log       string            `json:"log"`
+	Container string            `json:"container,omitempty"`
+	Errors    map[string]string `json:"errors,omitempty"`
+	Stopped   string            `json:"stopped,omitempty"`
+	Truncated bool              `json:"truncated,omitempty"`
+}
+
+// Output schemas of the pod tools.
+var (
+	ListOutput = mcp.WithOutputSchema[result.Items[podData]]()
+	GetOutput  = mcp.WithOutputSchema[podDetail]()
+	LogOutput  = mcp.WithOutputSchema[podLogData]()
+	WorkloadLogOutput = mcp.WithOutputSchema[workloadLogData]()
+	ExecOutput = mcp.WithOutputSchema[execData]()
+	CopyFromOutput = mcp.WithOutputSchema[copyFromData]()
+	DebugOutput = mcp.WithOutputSchema[debugData]()
+)
+
+// sortKeys are the sortBy values of the pod list tools.
+var sortKeys = map[string]view.Key[podData]{
+	"name": view.By(func(pod podData) string { return pod.Namespace + "/" + pod.Name }),
+	"creationTimestamp": view.By(func(pod podData) string { return pod.CreationTimestamp }),
+	"restarts": view.By(func(pod podData) int32 { return pod.Restarts }),
+}
+
+// restarts is the number of restarts of every container of pod.
+func restarts(pod *v1.Pod) int32 {
+	var count int32
+	for _, status := range pod.Status.InitContainerStatuses {
+		count += status.RestartCount
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		count += status.RestartCount
+	}
+	return count
 }
 
 func ListPodInNS(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for pod")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	labels := request.GetString("label", "")
+	fieldSelector := request.GetString("fieldSelector", "")
+	podView, err := view.New(request, sortKeys)
+	if err != nil {
+		return result.Invalid(err.Error())
+	}
 
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	pods, err := clientset.CoreV1().Pods(ns).List(context.TODO(), metav1.ListOptions{
-		LabelSelector: labels,
-	})
+	pods, err := clientset.CoreV1().Pods(ns).List(ctx, paging.Options(request, metav1.ListOptions{LabelSelector: labels, FieldSelector: fieldSelector}))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing pods in %s: %v", ns, err)), nil
+		return result.Error(err, "Error in listing pods in %s", ns)
 	}
 	var output []podData
 	for _, pod := range pods.Items {
@@ -46,69 +103,62 @@ func ListPodInNS(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToo
 			Namespace: pod.Namespace,
 			Status: string(pod.Status.Phase),
 			Labels: pod.Labels,
+			Restarts: restarts(&pod),
+			CreationTimestamp: pod.CreationTimestamp.UTC().Format(time.RFC3339),
 		})
 	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return podView.Apply(result.Page(output, &pods.ListMeta))
 }
 
 func ListPod (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	labels := request.GetString("label", "")
-	clientset, err := client.InitializeClients()
+	fieldSelector := request.GetString("fieldSelector", "")
+	podView, err := view.New(request, sortKeys)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Invalid(err.Error())
 	}
-	namespaces, err := clientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing namespace: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-
-	var output []podData
-	for _, namespace := range namespaces.Items {
-		pods, err := clientset.CoreV1().Pods(namespace.Name).List(context.TODO(), metav1.ListOptions{
-			LabelSelector: labels,
-		})
+	return podView.Apply(paging.AllNamespaces(ctx, request, clientset, "pod", metav1.ListOptions{LabelSelector: labels, FieldSelector: fieldSelector}, func(namespace string, opts metav1.ListOptions) ([]podData, metav1.ListInterface, error) {
+		pods, err := clientset.CoreV1().Pods(namespace).List(ctx, opts)
 		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Error in listing pod in %s: %v", namespace.Name, err)), nil
+			return nil, nil, err
 		}
-
+		var output []podData
 		for _, pod := range pods.Items {
 			output = append(output, podData{
 				Name: pod.Name,
 				Namespace: pod.Namespace,
 				Status: string(pod.Status.Phase),
 				Labels: pod.Labels,
+				Restarts: restarts(&pod),
+				CreationTimestamp: pod.CreationTimestamp.UTC().Format(time.RFC3339),
 			})
 		}
-	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+		return output, &pods.ListMeta, nil
+	}))
 }
 
 func GetPod (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for pod")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for pod")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	pod, err := clientset.CoreV1().Pods(ns).Get(context.TODO(), name, metav1.GetOptions{})
+	pod, err := clientset.CoreV1().Pods(ns).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in getting pods in %s/%s: %v", ns, name, err)), nil
+		return result.Error(err, "Error in getting pods in %s/%s", ns, name)
 	}
 
 	cName := make([]string, 0, len(pod.Spec.Containers))
@@ -123,61 +173,62 @@ func GetPod (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolRes
 		Status: string(pod.Status.Phase),
 		Labels: pod.Labels,
 		ContainerName: cName,
+		Restarts: restarts(pod),
+		CreationTimestamp: pod.CreationTimestamp.UTC().Format(time.RFC3339),
 	}
 	
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.JSON(describe(ctx, clientset, pod, output))
 }
 
 func DeletePod (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for pod")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for pod")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	err = clientset.CoreV1().Pods(ns).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	err = clientset.CoreV1().Pods(ns).Delete(ctx, name, options.Delete(request))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in deleting pods in %s/%s: %v", ns, name, err)), nil
+		return result.Error(err, "Error in deleting pods in %s/%s", ns, name)
 	}
 	output := fmt.Sprintf("Pod %s/%s is deleted", ns, name)
-	return mcp.NewToolResultText(string(output)), nil
+	if options.IsDryRun(request) {
+		output = fmt.Sprintf("Pod %s/%s would be deleted, dry run is enabled", ns, name)
+	}
+	return result.Text(output)
 }
 
 func UpdatePod (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for pod")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for pod")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	labels, err := request.RequireString("label")
 	if err != nil {
 		output := fmt.Sprintf("Provide label for pod")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	pod, err := clientset.CoreV1().Pods(ns).Get(context.TODO(), name, metav1.GetOptions{})
+	pod, err := clientset.CoreV1().Pods(ns).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in getting pods in %s/%s: %v", ns, name, err)), nil
+		return result.Error(err, "Error in getting pods in %s/%s", ns, name)
 	}
 	m := make(map[string]string)
 	label := strings.Split(labels, ",")
@@ -190,40 +241,43 @@ func UpdatePod (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallTool
 		}
 	}
 	pod.Labels = m
-	updatePod, err := clientset.CoreV1().Pods(ns).Update(context.TODO(), pod, metav1.UpdateOptions{})
+	updatePod, err := clientset.CoreV1().Pods(ns).Update(ctx, pod, options.Update(request))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in updating pod %s/%s with label %s: %v", ns, name, labels, err)), nil
+		return result.Error(err, "Error in updating pod %s/%s with label %s", ns, name, labels)
+	}
+	if options.IsDryRun(request) {
+		return options.DryRunResult(updatePod)
 	}
 	output := fmt.Sprintf("Successfully pod %s/%s updated with label %s", updatePod.Namespace, updatePod.Name, labels)
-	return mcp.NewToolResultText(string(output)), nil
+	return result.Text(output)
 }
 
 func CreatePod (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for pod")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name,err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for pod")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	labels := request.GetString("label", "")
 	containerNames, err := request.RequireString("containerNames")
 	if err != nil {
 		output := fmt.Sprintf("Provide container name for pod")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	containerImages, err := request.RequireString("containerImages")
 	if err != nil {
 		output := fmt.Sprintf("Provide image for pod")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	containerPorts := request.GetString("containerPorts", "http:8080")
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
 
 	lab := make(map[string]string)
@@ -244,7 +298,7 @@ func CreatePod (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallTool
 	cPorts := strings.Split(containerPorts, ",")
 
 	if len(cNames) != len(cImages) {
-		return mcp.NewToolResultText("container name and images counts are not matched"), nil
+		return result.Invalid("container name and images counts are not matched")
 	}
 
 	var containers []v1.Container
@@ -293,51 +347,390 @@ func CreatePod (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallTool
             Containers: containers,
         },
 	}
-	createPod, err := clientset.CoreV1().Pods(ns).Create(context.TODO(), pod, metav1.CreateOptions{})
+	createPod, err := clientset.CoreV1().Pods(ns).Create(ctx, pod, options.Create(request))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in creating pod %s/%s: %v", ns, name, err)), nil
+		return result.Error(err, "Error in creating pod %s/%s", ns, name)
+	}
+	if options.IsDryRun(request) {
+		return options.DryRunResult(createPod)
 	}
 	output := fmt.Sprintf("Successfully pod %s/%s is created", createPod.Namespace, createPod.Name)
-	return mcp.NewToolResultText(string(output)), nil
+	return result.Text(output)
 }
 
 func PodLog (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for pod")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name,err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for pod")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	tailLine  := request.GetInt("tailLine", 100)
-	containerName, err := request.RequireString("containerName")
+	containerName := request.GetString("containerName", "")
+	allContainers := request.GetBool("allContainers", false)
+	if allContainers && containerName != "" {
+		output := fmt.Sprintf("Provide either containerName or allContainers, not both")
+		return result.Invalid(output)
+	}
+	follow := request.GetBool("follow", false)
+	if follow && allContainers {
+		output := fmt.Sprintf("Provide either follow or allContainers, not both")
+		return result.Invalid(output)
+	}
+	followSeconds := request.GetInt("followSeconds", defaultFollowSeconds)
+	if followSeconds <= 0 || followSeconds > maxFollowSeconds {
+		output := fmt.Sprintf("Provide followSeconds between 1 and %d", maxFollowSeconds)
+		return result.Invalid(output)
+	}
+	podLogOptions, invalid := logOptions(request)
+	if invalid != "" {
+		return result.Invalid(invalid)
+	}
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		output := fmt.Sprintf("Provide container name for pod")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Error(err, "Error in intialize client")
+	}
+	if allContainers || containerName == "" {
+		pod, err := clientset.CoreV1().Pods(ns).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return result.Error(err, "Error in getting pods in %s/%s", ns, name)
+		}
+		if allContainers {
+			log, errors := allContainerLogs(ctx, clientset, pod, podLogOptions)
+			return mcp.NewToolResultStructured(podLogData{Log: log, Errors: errors}, log), nil
+		}
+		containerName, err = defaultContainer(pod)
+		if err != nil {
+			return result.Invalid(err.Error())
+		}
 	}
-	count := int64(tailLine)
-    podLogOptions := v1.PodLogOptions{
-        Container: containerName,
-        TailLines: &count,
+	podLogOptions.Container = containerName
+	if follow {
+		notifier := progress.New(ctx, request, "pod-log")
+		output, err := followLog(ctx, clientset, ns, name, podLogOptions, followSeconds, notifier)
+		if err != nil {
+			return result.Error(err, "Error in following the log for Pod %s/%s", ns, name)
+		}
+		return mcp.NewToolResultStructured(output, output.Log), nil
 	}
-	clientset, err := client.InitializeClients()
+	log, err := readLog(ctx, clientset, ns, name, podLogOptions)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in reading the log for Pod %s/%s", ns, name)
+	}
+	return mcp.NewToolResultStructured(podLogData{Log: log, Container: containerName}, log), nil
+}
+
+func WorkloadLogs (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ns, err := request.RequireString("namespace")
+	if err != nil {
+		output := fmt.Sprintf("Provide namespace for workload")
+		return result.Invalid(output)
+	}
+	kind := strings.ToLower(request.GetString("kind", ""))
+	name := request.GetString("name", "")
+	labelSelector := request.GetString("labelSelector", "")
+	if (kind == "") != (name == "") {
+		output := fmt.Sprintf("Provide both kind and name for workload")
+		return result.Invalid(output)
+	}
+	if (name == "") == (labelSelector == "") {
+		output := fmt.Sprintf("Provide either kind and name or labelSelector for workload")
+		return result.Invalid(output)
+	}
+	if kind != "" && !slices.Contains(workloadKinds, kind) {
+		output := fmt.Sprintf("Provide kind as one of %s", strings.Join(workloadKinds, ", "))
+		return result.Invalid(output)
+	}
+	var grep *regexp.Regexp
+	if pattern := request.GetString("grep", ""); pattern != "" {
+		grep, err = regexp.Compile(pattern)
+		if err != nil {
+			output := fmt.Sprintf("Provide grep as a regular expression: %v", err)
+			return result.Invalid(output)
+		}
+	}
+	maxLines := request.GetInt("maxLines", defaultMaxLines)
+	podLogOptions, invalid := logOptions(request)
+	if invalid != "" {
+		return result.Invalid(invalid)
 	}
-	req := clientset.CoreV1().Pods(ns).GetLogs(name, &podLogOptions)
-	podLog, err := req.Stream(context.TODO())
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		output := fmt.Sprintf("Error in streaming the log for Pod %s/%s", ns, name)
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	defer podLog.Close()
-	body, err := io.ReadAll(podLog)
+	if kind != "" {
+		labelSelector, err = workloadSelector(ctx, clientset, ns, kind, name)
+		if err != nil {
+			return result.Error(err, "Error in getting the pod selector of %s %s/%s", kind, ns, name)
+		}
+	}
+	pods, err := clientset.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
 	if err != nil {
-		output := fmt.Sprintf("Error in reading the log for Pod %s/%s", ns, name)
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Error(err, "Error in listing pods in %s with selector %s", ns, labelSelector)
 	}
-	return mcp.NewToolResultText(string(body)), nil
-}
\ No newline at end of file
+	lines, errors := podsLogs(ctx, clientset, pods.Items, request.GetString("containerName", ""), podLogOptions)
+	log, count, truncated := formatWorkloadLog(lines, grep, maxLines, podLogOptions.Timestamps)
+	output := workloadLogData{
+		Log:       log,
+		Selector:  labelSelector,
+		Pods:      make([]string, 0, len(pods.Items)),
+		Lines:     count,
+		Truncated: truncated,
+		Errors:    errors,
+	}
+	for _, pod := range pods.Items {
+		output.Pods = append(output.Pods, pod.Name)
+	}
+	return mcp.NewToolResultStructured(output, log), nil
+}
+
+// ExecPod returns the exec-pod handler. allowed is the server-side command
+// allowlist, commands it refuses are not run.
+func ExecPod (allowed func(command []string) bool) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ns, err := request.RequireString("namespace")
+		if err != nil {
+			output := fmt.Sprintf("Provide namespace for pod")
+			return result.Invalid(output)
+		}
+		name,err := request.RequireString("name")
+		if err != nil {
+			output := fmt.Sprintf("Provide name for pod")
+			return result.Invalid(output)
+		}
+		command, err := request.RequireStringSlice("command")
+		if err != nil || len(command) == 0 {
+			output := fmt.Sprintf("Provide command as a list of the program and its arguments. Ex: [\"ps\", \"aux\"]")
+			return result.Invalid(output)
+		}
+		if !allowed(command) {
+			output := fmt.Sprintf("Command %q is not in the exec allowlist of the server", strings.Join(command, " "))
+			return result.Forbidden(output)
+		}
+		limit := request.GetInt("maxOutputBytes", defaultExecOutputBytes)
+		if limit <= 0 || limit > maxExecOutputBytes {
+			output := fmt.Sprintf("Provide maxOutputBytes between 1 and %d", maxExecOutputBytes)
+			return result.Invalid(output)
+		}
+		cluster, err := client.GetCluster(ctx, request)
+		if err != nil {
+			return result.Error(err, "Error in intialize client")
+		}
+		containerName := request.GetString("containerName", "")
+		if containerName == "" {
+			pod, err := cluster.Clientset.CoreV1().Pods(ns).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return result.Error(err, "Error in getting pods in %s/%s", ns, name)
+			}
+			containerName, err = defaultContainer(pod)
+			if err != nil {
+				return result.Invalid(err.Error())
+			}
+		}
+		output, err := execCommand(ctx, cluster, ns, name, containerName, command, limit)
+		if err != nil {
+			return result.Error(err, "Error in running the command in Pod %s/%s", ns, name)
+		}
+		return result.JSON(output)
+	}
+}
+
+// CopyFromPod returns the copy-from-pod handler. allowed is the server-side
+// path allowlist, paths it refuses are not read. It is checked again on the
+// path with its symbolic links resolved in the container.
+func CopyFromPod (allowed func(name string, write, recursive bool) bool) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ns, err := request.RequireString("namespace")
+		if err != nil {
+			output := fmt.Sprintf("Provide namespace for pod")
+			return result.Invalid(output)
+		}
+		name,err := request.RequireString("name")
+		if err != nil {
+			output := fmt.Sprintf("Provide name for pod")
+			return result.Invalid(output)
+		}
+		src, err := request.RequireString("path")
+		if err != nil || !path.IsAbs(src) {
+			output := fmt.Sprintf("Provide path as an absolute path in the container. Ex: /etc/nginx/nginx.conf")
+			return result.Invalid(output)
+		}
+		src = path.Clean(src)
+		if !allowed(src, false, false) {
+			output := fmt.Sprintf("Path %s is not in the copy read paths of the server", src)
+			return result.Forbidden(output)
+		}
+		encoding := request.GetString("encoding", "")
+		if encoding != "" && encoding != "text" && encoding != "base64" {
+			output := fmt.Sprintf("Provide encoding as text or base64")
+			return result.Invalid(output)
+		}
+		limit := request.GetInt("maxBytes", defaultCopyBytes)
+		if limit <= 0 || limit > maxCopyBytes {
+			output := fmt.Sprintf("Provide maxBytes between 1 and %d", maxCopyBytes)
+			return result.Invalid(output)
+		}
+		cluster, err := client.GetCluster(ctx, request)
+		if err != nil {
+			return result.Error(err, "Error in intialize client")
+		}
+		containerName := request.GetString("containerName", "")
+		if containerName == "" {
+			pod, err := cluster.Clientset.CoreV1().Pods(ns).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return result.Error(err, "Error in getting pods in %s/%s", ns, name)
+			}
+			containerName, err = defaultContainer(pod)
+			if err != nil {
+				return result.Invalid(err.Error())
+			}
+		}
+		real, err := realPath(ctx, cluster, ns, name, containerName, src)
+		if err != nil {
+			return result.Error(err, "Error in copying %s from Pod %s/%s", src, ns, name)
+		}
+		if !allowed(real, false, false) {
+			output := fmt.Sprintf("Path %s resolves to %s, which is not in the copy read paths of the server", src, real)
+			return result.Forbidden(output)
+		}
+		output, err := copyFrom(ctx, cluster, ns, name, containerName, real, allowed(real, false, true), limit, encoding)
+		if errors.Is(err, errDirectory) {
+			output := fmt.Sprintf("Path %s is a directory, only copy read paths ending with /** allow directories", real)
+			return result.Forbidden(output)
+		}
+		if err != nil {
+			return result.Error(err, "Error in copying %s from Pod %s/%s", src, ns, name)
+		}
+		return result.JSON(output)
+	}
+}
+
+// CopyToPod returns the copy-to-pod handler. allowed is the server-side path
+// allowlist, paths it refuses are not written. It is checked again on the
+// path with its symbolic links resolved in the container.
+func CopyToPod (allowed func(name string, write, recursive bool) bool) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ns, err := request.RequireString("namespace")
+		if err != nil {
+			output := fmt.Sprintf("Provide namespace for pod")
+			return result.Invalid(output)
+		}
+		name,err := request.RequireString("name")
+		if err != nil {
+			output := fmt.Sprintf("Provide name for pod")
+			return result.Invalid(output)
+		}
+		dst, err := request.RequireString("path")
+		if err != nil || !path.IsAbs(dst) || path.Clean(dst) == "/" {
+			output := fmt.Sprintf("Provide path as the absolute path of the file to write in the container. Ex: /tmp/debug.conf")
+			return result.Invalid(output)
+		}
+		dst = path.Clean(dst)
+		if !allowed(dst, true, false) {
+			output := fmt.Sprintf("Path %s is not in the copy write paths of the server", dst)
+			return result.Forbidden(output)
+		}
+		text, err := request.RequireString("content")
+		if err != nil {
+			output := fmt.Sprintf("Provide content of the file")
+			return result.Invalid(output)
+		}
+		content := []byte(text)
+		switch request.GetString("encoding", "text") {
+		case "text":
+		case "base64":
+			content, err = base64.StdEncoding.DecodeString(text)
+			if err != nil {
+				output := fmt.Sprintf("Provide content as base64: %v", err)
+				return result.Invalid(output)
+			}
+		default:
+			output := fmt.Sprintf("Provide encoding as text or base64")
+			return result.Invalid(output)
+		}
+		if len(content) > maxCopyBytes {
+			output := fmt.Sprintf("Provide content of at most %d bytes", maxCopyBytes)
+			return result.Invalid(output)
+		}
+		cluster, err := client.GetCluster(ctx, request)
+		if err != nil {
+			return result.Error(err, "Error in intialize client")
+		}
+		containerName := request.GetString("containerName", "")
+		if containerName == "" {
+			pod, err := cluster.Clientset.CoreV1().Pods(ns).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return result.Error(err, "Error in getting pods in %s/%s", ns, name)
+			}
+			containerName, err = defaultContainer(pod)
+			if err != nil {
+				return result.Invalid(err.Error())
+			}
+		}
+		real, err := realPath(ctx, cluster, ns, name, containerName, dst)
+		if err != nil {
+			return result.Error(err, "Error in copying to %s in Pod %s/%s", dst, ns, name)
+		}
+		if real == "/" || !allowed(real, true, false) {
+			output := fmt.Sprintf("Path %s resolves to %s, which is not in the copy write paths of the server", dst, real)
+			return result.Forbidden(output)
+		}
+		dst = real
+		if err := copyTo(ctx, cluster, ns, name, containerName, dst, content); err != nil {
+			return result.Error(err, "Error in copying to %s in Pod %s/%s", dst, ns, name)
+		}
+		output := fmt.Sprintf("Copied %d bytes to %s in container %s of Pod %s/%s", len(content), dst, containerName, ns, name)
+		return result.Text(output)
+	}
+}
+
+// DebugPod returns the debug-pod handler. imageAllowed and commandAllowed are
+// the server-side debug image and exec allowlists, images or commands they
+// refuse are not started.
+func DebugPod (imageAllowed func(image string) bool, commandAllowed func(command []string) bool) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ns, err := request.RequireString("namespace")
+		if err != nil {
+			output := fmt.Sprintf("Provide namespace for pod")
+			return result.Invalid(output)
+		}
+		name,err := request.RequireString("name")
+		if err != nil {
+			output := fmt.Sprintf("Provide name for pod")
+			return result.Invalid(output)
+		}
+		image, err := request.RequireString("image")
+		if err != nil || image == "" {
+			output := fmt.Sprintf("Provide image of the debug container. Ex: busybox:1.36")
+			return result.Invalid(output)
+		}
+		if !imageAllowed(image) {
+			output := fmt.Sprintf("Image %s is not in the debug images of the server", image)
+			return result.Forbidden(output)
+		}
+		command := request.GetStringSlice("command", nil)
+		if len(command) > 0 && !commandAllowed(command) {
+			output := fmt.Sprintf("Command %q is not in the exec allowlist of the server", strings.Join(command, " "))
+			return result.Forbidden(output)
+		}
+		limit := request.GetInt("maxOutputBytes", defaultExecOutputBytes)
+		if limit <= 0 || limit > maxExecOutputBytes {
+			output := fmt.Sprintf("Provide maxOutputBytes between 1 and %d", maxExecOutputBytes)
+			return result.Invalid(output)
+		}
+		cluster, err := client.GetCluster(ctx, request)
+		if err != nil {
+			return result.Error(err, "Error in intialize client")
+		}
+		container := request.GetString("debugContainer", "")
+		target := request.GetString("targetContainer", "")
+		output, err := debugContainer(ctx, cluster, ns, name, container, image, target, command, limit)
+		if err != nil {
+			return result.Error(err, "Error in debugging Pod %s/%s", ns, name)
+		}
+		return result.JSON(output)
+	}
+}
@@ -0,0 +1,148 @@
+package pod
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// logConcurrency is the number of pods workload-logs reads at once.
+	logConcurrency = 5
+	// defaultMaxLines is the line budget of workload-logs when none is given.
+	defaultMaxLines = 1000
+)
+
+// workloadLogData is the log of workload-logs. Pods are the pods selected,
+// Errors the pod/container logs that could not be read. Truncated is set
+// when more than maxLines lines matched and only the most recent are kept.
+type workloadLogData struct {
+	Log       string            `json:"log"`
+	Selector  string            `json:"selector"`
+	Pods      []string          `json:"pods"`
+	Lines     int               `json:"lines"`
+	Truncated bool              `json:"truncated,omitempty"`
+	Errors    map[string]string `json:"errors,omitempty"`
+}
+
+// workloadKinds are the kind values of workload-logs.
+var workloadKinds = []string{"deployment", "statefulset", "daemonset", "job"}
+
+// workloadSelector returns the label selector of the pods of the workload
+// kind/name.
+func workloadSelector(ctx context.Context, clientset kubernetes.Interface, namespace, kind, name string) (string, error) {
+	var selector *metav1.LabelSelector
+	switch kind {
+	case "deployment":
+		deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		selector = deployment.Spec.Selector
+	case "statefulset":
+		statefulset, err := clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		selector = statefulset.Spec.Selector
+	case "daemonset":
+		daemonset, err := clientset.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		selector = daemonset.Spec.Selector
+	case "job":
+		job, err := clientset.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		selector = job.Spec.Selector
+	}
+	parsed, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return "", err
+	}
+	if parsed.Empty() {
+		return "", fmt.Errorf("%s %s/%s has no pod selector", kind, namespace, name)
+	}
+	return parsed.String(), nil
+}
+
+// podsLogs reads the logs of pods concurrently, logConcurrency pods at a
+// time. container is the container to read in each pod, every container of
+// the pod when empty. The lines are returned in time order, errors is keyed
+// by pod/container.
+func podsLogs(ctx context.Context, clientset kubernetes.Interface, pods []v1.Pod, container string, opts v1.PodLogOptions) ([]logLine, map[string]string) {
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		lines  []logLine
+		errors map[string]string
+	)
+	slots := make(chan struct{}, logConcurrency)
+	for i := range pods {
+		pod := &pods[i]
+		names := []string{container}
+		if container == "" {
+			names = names[:0]
+			for _, c := range pod.Spec.Containers {
+				names = append(names, c.Name)
+			}
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			podLines, podErrors := containerLogs(ctx, clientset, pod, names, opts)
+			mu.Lock()
+			defer mu.Unlock()
+			lines = append(lines, podLines...)
+			for name, err := range podErrors {
+				if errors == nil {
+					errors = map[string]string{}
+				}
+				errors[pod.Name+"/"+name] = err
+			}
+		}()
+	}
+	wg.Wait()
+	// The goroutines append in any order, sort by pod first so lines written
+	// at the same time keep a stable order.
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].pod < lines[j].pod
+	})
+	sortLines(lines)
+	return lines, errors
+}
+
+// formatWorkloadLog keeps the lines matching grep, the most recent maxLines
+// of them, and prefixes each with [pod/container]. It returns the log, the
+// number of lines kept and whether lines were dropped for the budget.
+func formatWorkloadLog(lines []logLine, grep *regexp.Regexp, maxLines int, timestamps bool) (string, int, bool) {
+	if grep != nil {
+		matched := lines[:0]
+		for _, line := range lines {
+			if grep.MatchString(line.text) {
+				matched = append(matched, line)
+			}
+		}
+		lines = matched
+	}
+	truncated := maxLines > 0 && len(lines) > maxLines
+	if truncated {
+		lines = lines[len(lines)-maxLines:]
+	}
+	var output strings.Builder
+	for _, line := range lines {
+		output.WriteString(formatLine("["+line.pod+"/"+line.container+"] ", line, timestamps))
+	}
+	return output.String(), len(lines), truncated
+}
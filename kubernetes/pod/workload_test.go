@@ -0,0 +1,84 @@
+package pod
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestFormatWorkloadLog(t *testing.T) {
+	logs := [][3]string{
+		{"web-b", "app", "2026-10-18T10:00:01Z GET /\n2026-10-18T10:00:04Z error: timeout\n"},
+		{"web-a", "app", "2026-10-18T10:00:02Z GET /health\n2026-10-18T10:00:03Z error: refused\n\tat dial\n"},
+		{"web-a", "proxy", "2026-10-18T10:00:02Z upstream ok\n"},
+	}
+	tests := []struct {
+		grep          string
+		maxLines      int
+		timestamps    bool
+		want          string
+		wantTruncated bool
+	}{
+		{"", 0, false, "[web-b/app] GET /\n[web-a/app] GET /health\n[web-a/proxy] upstream ok\n[web-a/app] error: refused\n[web-a/app] \tat dial\n[web-b/app] error: timeout\n", false},
+		{"", 2, false, "[web-a/app] \tat dial\n[web-b/app] error: timeout\n", true},
+		{"", 6, false, "[web-b/app] GET /\n[web-a/app] GET /health\n[web-a/proxy] upstream ok\n[web-a/app] error: refused\n[web-a/app] \tat dial\n[web-b/app] error: timeout\n", false},
+		{"^error", 0, true, "[web-a/app] 2026-10-18T10:00:03Z error: refused\n[web-b/app] 2026-10-18T10:00:04Z error: timeout\n", false},
+		{"GET|error", 3, false, "[web-a/app] GET /health\n[web-a/app] error: refused\n[web-b/app] error: timeout\n", true},
+		{"nothing", 5, false, "", false},
+	}
+	for _, test := range tests {
+		var lines []logLine
+		for _, log := range logs {
+			for _, line := range splitLog(log[1], log[2]) {
+				line.pod = log[0]
+				lines = append(lines, line)
+			}
+		}
+		sortLines(lines)
+		var grep *regexp.Regexp
+		if test.grep != "" {
+			grep = regexp.MustCompile(test.grep)
+		}
+		got, count, truncated := formatWorkloadLog(lines, grep, test.maxLines, test.timestamps)
+		if got != test.want || truncated != test.wantTruncated || count != strings.Count(test.want, "\n") {
+			t.Errorf("formatWorkloadLog(%q, %d, %v) = %q, %d, %v, want %q, %v", test.grep, test.maxLines, test.timestamps, got, count, truncated, test.want, test.wantTruncated)
+		}
+	}
+}
+
+func TestPodsLogs(t *testing.T) {
+	var pods []v1.Pod
+	for _, name := range []string{"web-c", "web-a", "web-b"} {
+		pods = append(pods, v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "app"}, {Name: "proxy"}}},
+		})
+	}
+	tests := []struct {
+		container string
+		want      string
+	}{
+		// The fake logs have no timestamp, the lines are ordered by pod and
+		// keep the container order within a pod.
+		{"", "web-a/app web-a/proxy web-b/app web-b/proxy web-c/app web-c/proxy"},
+		{"proxy", "web-a/proxy web-b/proxy web-c/proxy"},
+	}
+	for _, test := range tests {
+		lines, errors := podsLogs(context.Background(), fake.NewClientset(), pods, test.container, v1.PodLogOptions{})
+		if errors != nil {
+			t.Errorf("podsLogs(%q) errors = %v", test.container, errors)
+		}
+		var got []string
+		for _, line := range lines {
+			got = append(got, line.pod+"/"+line.container)
+		}
+		if strings.Join(got, " ") != test.want {
+			t.Errorf("podsLogs(%q) = %q, want %q", test.container, strings.Join(got, " "), test.want)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package progress
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Notifier sends the output of a long running tool call to the client while
+// the call runs. A client that passes a progressToken in the request _meta
+// receives notifications/progress with the output as message, other clients
+// receive it as notifications/message at the info level once they set their
+// logging level to info or lower.
+type Notifier struct {
+	server *server.MCPServer
+	token  mcp.ProgressToken
+	logger string
+	count  float64
+}
+
+// New returns the notifier of request. logger names the source of the
+// logging notifications, usually the tool name.
+func New(ctx context.Context, request mcp.CallToolRequest, logger string) *Notifier {
+	n := &Notifier{server: server.ServerFromContext(ctx), logger: logger}
+	if request.Params.Meta != nil {
+		n.token = request.Params.Meta.ProgressToken
+	}
+	return n
+}
+
+// Send sends message to the client. Notifications that cannot be delivered,
+// because the session is gone or its queue is full, are dropped. The tool
+// result may not make up for them, pod-log only keeps the last 1000 followed
+// lines and marks its result as truncated when it drops older ones.
+func (n *Notifier) Send(ctx context.Context, message string) {
+	if n.server == nil {
+		return
+	}
+	n.count++
+	if n.token != nil {
+		_ = n.server.SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+			"progressToken": n.token,
+			"progress":      n.count,
+			"message":       message,
+		})
+		return
+	}
+	_ = n.server.SendLogMessageToClient(ctx, mcp.NewLoggingMessageNotification(mcp.LoggingLevelInfo, n.logger, message))
+}
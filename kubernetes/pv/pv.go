@@ -3,9 +3,13 @@ package pv
 import (
 	"fmt"
 	"context"
-	"encoding/json"
 	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/paging"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/options"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/confirm"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	v1 "k8s.io/api/core/v1"
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -19,14 +23,20 @@ type pvData struct {
 	StorageClass string   `json:"storageClass,omitempty"`
 }
 
+// Output schemas of the pv tools.
+var (
+	ListOutput = mcp.WithOutputSchema[result.Items[pvData]]()
+	GetOutput  = mcp.WithOutputSchema[pvData]()
+)
+
 func ListPV(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	pvolume, err := clientset.CoreV1().PersistentVolumes().List(context.TODO(), metav1.ListOptions{})
+	pvolume, err := clientset.CoreV1().PersistentVolumes().List(ctx, paging.Options(request, metav1.ListOptions{}))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing pv: %v", err)), nil
+		return result.Error(err, "Error in listing pv")
 	}
 	var output []pvData
 	for _, pv := range pvolume.Items {
@@ -38,26 +48,22 @@ func ListPV(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResu
 			Status: string(pv.Status.Phase),
 		})
 	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.Page(output, &pvolume.ListMeta)
 }
 
 func GetPV(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for pv")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	pv, err := clientset.CoreV1().PersistentVolumes().Get(context.TODO(), name, metav1.GetOptions{})
+	pv, err := clientset.CoreV1().PersistentVolumes().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in getting pv in %s: %v", name, err)), nil
+		return result.Error(err, "Error in getting pv in %s", name)
 	}
 
 	var accMode []string
@@ -74,27 +80,74 @@ func GetPV(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResul
 		StorageClass: pv.Spec.StorageClassName,
 		Status: string(pv.Status.Phase),
 	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.JSON(output)
 }
 
 func DeletePV(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for pv")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	err = clientset.CoreV1().PersistentVolumes().Delete(context.TODO(), name, metav1.DeleteOptions{})
+	key := confirm.Key(ctx, request, name)
+	if !options.IsDryRun(request) {
+		token := confirm.Token(request)
+		if token == "" {
+			preview, err := deletePreview(ctx, clientset, name)
+			if err != nil {
+				return result.Error(err, "Error in preparing delete preview for pv %s", name)
+			}
+			message := fmt.Sprintf("Deleting pv %s removes the volume listed in the preview. Call delete-pv again with the confirmationToken to delete it", name)
+			return confirm.PreviewResult(message, key, preview)
+		}
+		if err := confirm.Consume(token, key); err != nil {
+			return result.Error(err, "PV %s is not deleted", name)
+		}
+	}
+	err = clientset.CoreV1().PersistentVolumes().Delete(ctx, name, options.Delete(request))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in deleting pv %s: %v", name, err)), nil
+		return result.Error(err, "Error in deleting pv %s", name)
 	}
 	output := fmt.Sprintf("Successfully pv %s is deleted", name)
-	return mcp.NewToolResultText(string(output)), nil
-}
\ No newline at end of file
+	if options.IsDryRun(request) {
+		output = fmt.Sprintf("PV %s would be deleted, dry run is enabled", name)
+	}
+	return result.Text(output)
+}
+
+type pvPreview struct {
+	pvData
+	ReclaimPolicy string `json:"reclaimPolicy,omitempty"`
+	Claim         string `json:"claim,omitempty"`
+	Warning       string `json:"warning,omitempty"`
+}
+
+// deletePreview shows the volume, the claim bound to it and whether the
+// backing storage is removed along with it.
+func deletePreview(ctx context.Context, clientset kubernetes.Interface, name string) (*pvPreview, error) {
+	pv, err := clientset.CoreV1().PersistentVolumes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	qty := pv.Spec.Capacity[v1.ResourceStorage]
+	preview := &pvPreview{
+		pvData: pvData{
+			Name: pv.Name,
+			Capacity: qty.String(),
+			StorageClass: pv.Spec.StorageClassName,
+			Status: string(pv.Status.Phase),
+		},
+		ReclaimPolicy: string(pv.Spec.PersistentVolumeReclaimPolicy),
+	}
+	if pv.Spec.ClaimRef != nil {
+		preview.Claim = pv.Spec.ClaimRef.Namespace + "/" + pv.Spec.ClaimRef.Name
+	}
+	if pv.Spec.PersistentVolumeReclaimPolicy == v1.PersistentVolumeReclaimDelete {
+		preview.Warning = "reclaim policy is Delete, the backing storage and its data are deleted with the volume"
+	}
+	return preview, nil
+}
@@ -3,9 +3,13 @@ package pvc
 import (
 	"fmt"
 	"context"
-	"encoding/json"
+	"time"
 	"strings"
 	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/paging"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/options"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/view"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/api/core/v1"
@@ -20,87 +24,100 @@ type pvcData struct {
 	AccessMode   []string `json:"accessMode,omitempty"`
 	StorageClass string   `json:"storageClass,omitempty"`
 	Volume       string   `json:"volume,omitempty"`
+	CreationTimestamp string `json:"creationTimestamp,omitempty"`
+}
+
+// Output schemas of the pvc tools.
+var (
+	ListOutput = mcp.WithOutputSchema[result.Items[pvcData]]()
+	GetOutput  = mcp.WithOutputSchema[pvcData]()
+)
+
+// sortKeys are the sortBy values of the pvc list tools.
+var sortKeys = map[string]view.Key[pvcData]{
+	"name": view.By(func(pvc pvcData) string { return pvc.Namespace + "/" + pvc.Name }),
+	"creationTimestamp": view.By(func(pvc pvcData) string { return pvc.CreationTimestamp }),
 }
 
 func ListPVCInNS(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for pvc")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	fieldSelector := request.GetString("fieldSelector", "")
+	pvcView, err := view.New(request, sortKeys)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Invalid(err.Error())
 	}
-	pvcs, err := clientset.CoreV1().PersistentVolumeClaims(ns).List(context.TODO(), metav1.ListOptions{})
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing pvc in %s: %v", ns, err)), nil
+		return result.Error(err, "Error in intialize client")
+	}
+	pvcs, err := clientset.CoreV1().PersistentVolumeClaims(ns).List(ctx, paging.Options(request, metav1.ListOptions{FieldSelector: fieldSelector}))
+	if err != nil {
+		return result.Error(err, "Error in listing pvc in %s", ns)
 	}
 	var output []pvcData
 	for _, pvc := range pvcs.Items {
 		qty := pvc.Spec.Resources.Requests[v1.ResourceStorage]
 		output = append(output, pvcData{
 			Name: pvc.Name,
+			CreationTimestamp: pvc.CreationTimestamp.UTC().Format(time.RFC3339),
 			Namespace: pvc.Namespace,
 			Capacity: qty.String(),
 			Status: string(pvc.Status.Phase),
 		})
 	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return pvcView.Apply(result.Page(output, &pvcs.ListMeta))
 }
 
 func ListPVC (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	clientset, err := client.InitializeClients()
+	fieldSelector := request.GetString("fieldSelector", "")
+	pvcView, err := view.New(request, sortKeys)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Invalid(err.Error())
 	}
-	namespaces, err := clientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing namespace: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	var output []pvcData
-	for _, namespace := range namespaces.Items {
-		pvcs, err := clientset.CoreV1().PersistentVolumeClaims(namespace.Name).List(context.TODO(), metav1.ListOptions{})
+	return pvcView.Apply(paging.AllNamespaces(ctx, request, clientset, "pvc", metav1.ListOptions{FieldSelector: fieldSelector}, func(namespace string, opts metav1.ListOptions) ([]pvcData, metav1.ListInterface, error) {
+		pvcs, err := clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, opts)
 		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Error in listing pvc in %s: %v", namespace.Name, err)), nil
+			return nil, nil, err
 		}
+		var output []pvcData
 		for _, pvc := range pvcs.Items {
 			output = append(output, pvcData{
 				Name: pvc.Name,
+				CreationTimestamp: pvc.CreationTimestamp.UTC().Format(time.RFC3339),
 				Namespace: pvc.Namespace,
 				Status: string(pvc.Status.Phase),
 			})
 		}
-	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+		return output, &pvcs.ListMeta, nil
+	}))
 }
 
 func GetPVC(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for pvc")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for pvc")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	pvc, err := clientset.CoreV1().PersistentVolumeClaims(ns).Get(context.TODO(), name, metav1.GetOptions{})
+	pvc, err := clientset.CoreV1().PersistentVolumeClaims(ns).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in getting pvc in %s/%s: %v", ns, name, err)), nil
+		return result.Error(err, "Error in getting pvc in %s/%s", ns, name)
 	}
 	var accMode []string
 	for _, mode := range pvc.Spec.AccessModes {
@@ -110,6 +127,7 @@ func GetPVC(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResu
 	
 	output := pvcData{
 		Name: pvc.Name,
+		CreationTimestamp: pvc.CreationTimestamp.UTC().Format(time.RFC3339),
 		Namespace: pvc.Namespace,
 		Capacity: qty.String(),
 		AccessMode: accMode,
@@ -117,97 +135,99 @@ func GetPVC(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResu
 		Volume: pvc.Spec.VolumeName,
 		Status: string(pvc.Status.Phase),
 	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.JSON(output)
 }
 
 func DeletePVC(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for pvc")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for pvc")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	err = clientset.CoreV1().PersistentVolumeClaims(ns).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	err = clientset.CoreV1().PersistentVolumeClaims(ns).Delete(ctx, name, options.Delete(request))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in deleting pvc in %s/%s: %v", ns, name, err)), nil
+		return result.Error(err, "Error in deleting pvc in %s/%s", ns, name)
 	}
 	output := fmt.Sprintf("Successfully pvc %s/%s is deleted", ns, name)
-	return mcp.NewToolResultText(string(output)), nil
+	if options.IsDryRun(request) {
+		output = fmt.Sprintf("PVC %s/%s would be deleted, dry run is enabled", ns, name)
+	}
+	return result.Text(output)
 }
 
 func UpdatePVC(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for pvc")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for pvc")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	size, err := request.RequireString("size")
 	if err != nil {
 		output := fmt.Sprintf("Provide size for pvc")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	pvc, err := clientset.CoreV1().PersistentVolumeClaims(ns).Get(context.TODO(), name, metav1.GetOptions{})
+	pvc, err := clientset.CoreV1().PersistentVolumeClaims(ns).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in getting pvc in %s/%s: %v", ns, name, err)), nil
+		return result.Error(err, "Error in getting pvc in %s/%s", ns, name)
 	}
 
 	qty, err := resource.ParseQuantity(size)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Invalid pvc size: %s", size)), nil
+		return result.Invalid(fmt.Sprintf("Invalid pvc size: %s", size))
 	}
 
 	pvc.Spec.Resources.Requests[v1.ResourceStorage] = qty
 
-	updatePVC, err :=  clientset.CoreV1().PersistentVolumeClaims(ns).Update(context.TODO(), pvc, metav1.UpdateOptions{})
+	updatePVC, err :=  clientset.CoreV1().PersistentVolumeClaims(ns).Update(ctx, pvc, options.Update(request))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in updating pvc in %s/%s with size %s: %v", ns, name, size, err)), nil
+		return result.Error(err, "Error in updating pvc in %s/%s with size %s", ns, name, size)
+	}
+	if options.IsDryRun(request) {
+		return options.DryRunResult(updatePVC)
 	}
 	output := fmt.Sprintf("Successfully pvc %s/%s updated with size %s", updatePVC.Namespace, updatePVC.Name, size)
-	return mcp.NewToolResultText(string(output)), nil
+	return result.Text(output)
 }
 
 func CreatePVC(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for pvc")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for pvc")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	size, err := request.RequireString("size")
 	if err != nil {
 		output := fmt.Sprintf("Provide size for pvc")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	accessModes := request.GetString("accessMode", "ReadWriteOnce")
 	storageClass, err := request.RequireString("storageClass")
 	if err != nil {
 		output := fmt.Sprintf("Provide storageClass name for pvc")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	var accMode []v1.PersistentVolumeAccessMode
 	am := strings.Split(accessModes, ",")
@@ -215,9 +235,9 @@ func CreatePVC(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolR
 		accMode = append(accMode, v1.PersistentVolumeAccessMode(mode))
 	}
 
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
 	
 	pvc := &v1.PersistentVolumeClaim{
@@ -235,10 +255,13 @@ func CreatePVC(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolR
 			StorageClassName: &storageClass,
 		},
 	}
-	createPVC, err := clientset.CoreV1().PersistentVolumeClaims(ns).Create(context.TODO(), pvc, metav1.CreateOptions{})
+	createPVC, err := clientset.CoreV1().PersistentVolumeClaims(ns).Create(ctx, pvc, options.Create(request))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in creating pvc %s/%s: %v", ns, name, err)), nil
+		return result.Error(err, "Error in creating pvc %s/%s", ns, name)
+	}
+	if options.IsDryRun(request) {
+		return options.DryRunResult(createPVC)
 	}
 	output := fmt.Sprintf("Successfully pvc %s/%s is created", createPVC.Namespace, createPVC.Name)
-	return mcp.NewToolResultText(string(output)), nil
+	return result.Text(output)
 }
\ No newline at end of file
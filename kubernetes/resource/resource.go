@@ -0,0 +1,206 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/options"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/paging"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"time"
+)
+
+type resourceData struct {
+	APIVersion        string            `json:"apiVersion,omitempty"`
+	Kind              string            `json:"kind,omitempty"`
+	Name              string            `json:"name,omitempty"`
+	Namespace         string            `json:"namespace,omitempty"`
+	Labels            map[string]string `json:"labels,omitempty"`
+	CreationTimestamp string            `json:"creationTimestamp,omitempty"`
+}
+
+// Output schemas of the generic resource tools. Get returns the object as the
+// API server serves it.
+var (
+	ListOutput = mcp.WithOutputSchema[result.Items[resourceData]]()
+	GetOutput  = mcp.WithOutputSchema[map[string]interface{}]()
+)
+
+// target is a single object addressed by a get, delete or patch call.
+type target struct {
+	mapping   *meta.RESTMapping
+	resource  dynamic.ResourceInterface
+	name      string
+	namespace string
+}
+
+func (t *target) String() string {
+	if t.namespace == "" {
+		return fmt.Sprintf("%s %s", t.mapping.Resource.GroupResource().String(), t.name)
+	}
+	return fmt.Sprintf("%s %s/%s", t.mapping.Resource.GroupResource().String(), t.namespace, t.name)
+}
+
+// Kinds with a dedicated delete tool that asks for confirmation first.
+var confirmedDeletes = map[schema.GroupResource]string{
+	{Group: "", Resource: "namespaces"}:        "delete-ns",
+	{Group: "", Resource: "nodes"}:             "delete-node",
+	{Group: "", Resource: "persistentvolumes"}: "delete-pv",
+	{Group: "apps", Resource: "deployments"}:   "delete-deployment",
+}
+
+var patchTypes = map[string]types.PatchType{
+	"merge":     types.MergePatchType,
+	"json":      types.JSONPatchType,
+	"strategic": types.StrategicMergePatchType,
+}
+
+func ListResource(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	kind, err := request.RequireString("resource")
+	if err != nil {
+		output := fmt.Sprintf("Provide resource to list like deployments, certificates.cert-manager.io or ServiceMonitor")
+		return result.Invalid(output)
+	}
+	ns := request.GetString("namespace", "")
+	labels := request.GetString("label", "")
+	fieldSelector := request.GetString("fieldSelector", "")
+
+	cluster, err := client.GetCluster(ctx, request)
+	if err != nil {
+		return result.Error(err, "Error in intialize client")
+	}
+	mapping, err := cluster.RESTMapping(kind, request.GetString("apiVersion", ""))
+	if err != nil {
+		return result.Error(err, "Error in resolving resource %s", kind)
+	}
+	var resource dynamic.ResourceInterface = cluster.Dynamic.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		resource = cluster.Dynamic.Resource(mapping.Resource).Namespace(ns)
+	}
+	list, err := resource.List(ctx, paging.Options(request, metav1.ListOptions{
+		LabelSelector: labels,
+		FieldSelector: fieldSelector,
+	}))
+	if err != nil {
+		return result.Error(err, "Error in listing %s", mapping.Resource.GroupResource().String())
+	}
+	var output []resourceData
+	for _, item := range list.Items {
+		output = append(output, resourceData{
+			APIVersion:        item.GetAPIVersion(),
+			Kind:              item.GetKind(),
+			Name:              item.GetName(),
+			Namespace:         item.GetNamespace(),
+			Labels:            item.GetLabels(),
+			CreationTimestamp: item.GetCreationTimestamp().UTC().Format(time.RFC3339),
+		})
+	}
+	return result.Page(output, list)
+}
+
+func GetResource(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	object, failure := lookup(ctx, request)
+	if failure != nil {
+		return failure, nil
+	}
+	item, err := object.resource.Get(ctx, object.name, metav1.GetOptions{})
+	if err != nil {
+		return result.Error(err, "Error in getting %s", object)
+	}
+	item.SetManagedFields(nil)
+	return result.JSON(item)
+}
+
+func DeleteResource(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	object, failure := lookup(ctx, request)
+	if failure != nil {
+		return failure, nil
+	}
+	if tool, ok := confirmedDeletes[object.mapping.Resource.GroupResource()]; ok && !options.IsDryRun(request) {
+		output := fmt.Sprintf("Deleting %s needs confirmation, use the %s tool", object, tool)
+		return result.Invalid(output)
+	}
+	err := object.resource.Delete(ctx, object.name, options.Delete(request))
+	if err != nil {
+		return result.Error(err, "Error in deleting %s", object)
+	}
+	output := fmt.Sprintf("%s is deleted", object)
+	if options.IsDryRun(request) {
+		output = fmt.Sprintf("%s would be deleted, dry run is enabled", object)
+	}
+	return result.Text(output)
+}
+
+func PatchResource(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	patch, err := request.RequireString("patch")
+	if err != nil {
+		output := fmt.Sprintf("Provide the patch to apply")
+		return result.Invalid(output)
+	}
+	patchType, ok := patchTypes[request.GetString("patchType", "merge")]
+	if !ok {
+		output := fmt.Sprintf("Patch type %s is not supported, use merge, json or strategic", request.GetString("patchType", ""))
+		return result.Invalid(output)
+	}
+	object, failure := lookup(ctx, request)
+	if failure != nil {
+		return failure, nil
+	}
+	patched, err := object.resource.Patch(ctx, object.name, patchType, []byte(patch), metav1.PatchOptions{
+		DryRun: options.DryRun(request),
+	})
+	if err != nil {
+		return result.Error(err, "Error in patching %s", object)
+	}
+	if options.IsDryRun(request) {
+		return options.DryRunResult(patched)
+	}
+	output := fmt.Sprintf("Successfully %s is patched", object)
+	return result.Text(output)
+}
+
+// lookup resolves the resource, namespace and name arguments of a call that
+// targets a single object. It returns the failed result for the caller when
+// they cannot be resolved.
+func lookup(ctx context.Context, request mcp.CallToolRequest) (*target, *mcp.CallToolResult) {
+	kind, err := request.RequireString("resource")
+	if err != nil {
+		failure, _ := result.Invalid("Provide resource like deployments, certificates.cert-manager.io or ServiceMonitor")
+		return nil, failure
+	}
+	name, err := request.RequireString("name")
+	if err != nil {
+		failure, _ := result.Invalid(fmt.Sprintf("Provide name for %s", kind))
+		return nil, failure
+	}
+	cluster, err := client.GetCluster(ctx, request)
+	if err != nil {
+		failure, _ := result.Error(err, "Error in intialize client")
+		return nil, failure
+	}
+	mapping, err := cluster.RESTMapping(kind, request.GetString("apiVersion", ""))
+	if err != nil {
+		failure, _ := result.Error(err, "Error in resolving resource %s", kind)
+		return nil, failure
+	}
+	object := &target{
+		mapping:  mapping,
+		resource: cluster.Dynamic.Resource(mapping.Resource),
+		name:     name,
+	}
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		object.namespace = request.GetString("namespace", "")
+		if object.namespace == "" {
+			failure, _ := result.Invalid(fmt.Sprintf("Provide namespace for %s, it is a namespaced resource", mapping.Resource.GroupResource().String()))
+			return nil, failure
+		}
+		object.resource = cluster.Dynamic.Resource(mapping.Resource).Namespace(object.namespace)
+	}
+	return object, nil
+}
@@ -0,0 +1,142 @@
+package result
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Items is the structured output of the list tools, MCP structured content
+// has to be a JSON object so lists are wrapped. Continue is set when more
+// items are left, pass it back with the same arguments to get the next page.
+// SkippedNamespaces lists the namespaces a list across all namespaces could
+// not read because the caller is not allowed to. OnlyNamespaces is set when
+// the caller may not list the namespaces either, the list then only covers
+// these namespaces.
+type Items[T any] struct {
+	Items              []T      `json:"items"`
+	Continue           string   `json:"continue,omitempty"`
+	RemainingItemCount *int64   `json:"remainingItemCount,omitempty"`
+	SkippedNamespaces  []string `json:"skippedNamespaces,omitempty"`
+	OnlyNamespaces     []string `json:"onlyNamespaces,omitempty"`
+}
+
+// Change is the structured output of the tools that create, update or
+// delete objects. Message is set when the change is persisted, DryRun and
+// Object when it only ran on the server with dryRun=All.
+type Change struct {
+	Message string         `json:"message,omitempty"`
+	DryRun  bool           `json:"dryRun,omitempty"`
+	Object  runtime.Object `json:"object,omitempty"`
+}
+
+// Status describes why a tool call failed. Reason and Code are the ones the
+// API server returned, they are empty when the failure did not come from it.
+type Status struct {
+	Message string              `json:"message"`
+	Reason  metav1.StatusReason `json:"reason,omitempty"`
+	Code    int32               `json:"code,omitempty"`
+}
+
+type failure struct {
+	Error Status `json:"error"`
+}
+
+// ChangeOutput is the output schema of the tools returning a Change.
+var ChangeOutput = mcp.WithOutputSchema[Change]()
+
+// JSON returns output as structured content, with its indented JSON as the
+// text content for clients that do not read structured content.
+func JSON(output interface{}) (*mcp.CallToolResult, error) {
+	mcpOutput, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return Error(err, "Error in marshalling")
+	}
+	return mcp.NewToolResultStructured(output, string(mcpOutput)), nil
+}
+
+// List returns items wrapped in Items, an empty list is returned as [] and
+// not null.
+func List[T any](items []T) (*mcp.CallToolResult, error) {
+	if items == nil {
+		items = []T{}
+	}
+	return JSON(Items[T]{Items: items})
+}
+
+// Page returns items with the continue token and remaining item count of
+// the list they were read from.
+func Page[T any](items []T, list metav1.ListInterface) (*mcp.CallToolResult, error) {
+	if items == nil {
+		items = []T{}
+	}
+	return JSON(Items[T]{
+		Items:              items,
+		Continue:           list.GetContinue(),
+		RemainingItemCount: list.GetRemainingItemCount(),
+	})
+}
+
+// Text returns message as the outcome of a change.
+func Text(message string) (*mcp.CallToolResult, error) {
+	return mcp.NewToolResultStructured(Change{Message: message}, message), nil
+}
+
+// Invalid returns a failed result for a call that cannot run as requested,
+// like a missing argument. It is not sent to the API server.
+func Invalid(message string) (*mcp.CallToolResult, error) {
+	return fail(Status{
+		Message: message,
+		Reason:  metav1.StatusReasonBadRequest,
+		Code:    400,
+	})
+}
+
+// Forbidden returns a failed result for a call the server policy refuses.
+// It is not sent to the API server.
+func Forbidden(message string) (*mcp.CallToolResult, error) {
+	return fail(Status{
+		Message: message,
+		Reason:  metav1.StatusReasonForbidden,
+		Code:    403,
+	})
+}
+
+// Error returns a failed result for err. The message is formatted like
+// fmt.Sprintf(format, args...) followed by the error, and the reason is
+// taken from the API status when err came from the API server so agents can
+// branch on NotFound, Forbidden, Conflict or AlreadyExists. A call that ran
+// out of time is reported as Timeout.
+func Error(err error, format string, args ...interface{}) (*mcp.CallToolResult, error) {
+	status := Status{
+		Message: fmt.Sprintf("%s: %v", fmt.Sprintf(format, args...), err),
+		Reason:  apierrors.ReasonForError(err),
+	}
+	var apiStatus apierrors.APIStatus
+	if errors.As(err, &apiStatus) {
+		status.Code = apiStatus.Status().Code
+	} else if errors.Is(err, context.DeadlineExceeded) {
+		status.Reason = metav1.StatusReasonTimeout
+		status.Code = 504
+	}
+	return fail(status)
+}
+
+// fail returns status as the JSON text of a failed result. It is not sent
+// as structured content, since that would have to match the output schema of
+// the tool.
+func fail(status Status) (*mcp.CallToolResult, error) {
+	text, err := json.MarshalIndent(failure{Error: status}, "", " ")
+	if err != nil {
+		text = []byte(status.Message)
+	}
+	output := mcp.NewToolResultText(string(text))
+	output.IsError = true
+	return output, nil
+}
@@ -3,8 +3,9 @@ package role
 import (
 	"fmt"
 	"context"
-	"encoding/json"
 	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/paging"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -15,6 +16,12 @@ type roleData struct {
 	Rules        []rules   ` json:"rules,omitempty"`
 }
 
+// Output schemas of the role tools.
+var (
+	ListOutput = mcp.WithOutputSchema[result.Items[roleData]]()
+	GetOutput  = mcp.WithOutputSchema[roleData]()
+)
+
 type rules struct {
 	ApiGroups    []string  `json:"apiGroups,omitempty"`
 	Resources    []string  `json:"resources,omitempty"`
@@ -25,15 +32,15 @@ func ListRoleInNS(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallTo
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for role")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	roles, err := clientset.RbacV1().Roles(ns).List(context.TODO(), metav1.ListOptions{})
+	roles, err := clientset.RbacV1().Roles(ns).List(ctx, paging.Options(request, metav1.ListOptions{}))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing role in %s: %v", ns, err)), nil
+		return result.Error(err, "Error in listing role in %s", ns)
 	}
 	var output []roleData
 	for _, role := range roles.Items {
@@ -42,61 +49,48 @@ func ListRoleInNS(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallTo
 			Namespace: role.Namespace,
 		})
 	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.Page(output, &roles.ListMeta)
 }
 
 func ListRole(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	clientset, err := client.InitializeClients()
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
-	}
-	namespaces, err := clientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing namespace: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	var output []roleData
-	for _, namespace := range namespaces.Items {
-		roles, err := clientset.RbacV1().Roles(namespace.Name).List(context.TODO(), metav1.ListOptions{})
+	return paging.AllNamespaces(ctx, request, clientset, "role", metav1.ListOptions{}, func(namespace string, opts metav1.ListOptions) ([]roleData, metav1.ListInterface, error) {
+		roles, err := clientset.RbacV1().Roles(namespace).List(ctx, opts)
 		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Error in listing role in namespace %s: %v", namespace.Name, err)), nil
+			return nil, nil, err
 		}
-		
+		var output []roleData
 		for _, role := range roles.Items {
 			output = append(output, roleData{
 				Name: role.Name,
 				Namespace: role.Namespace,
 			})
 		}
-	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+		return output, &roles.ListMeta, nil
+	})
 }
 
 func GetRole(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for role")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for role")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	role, err := clientset.RbacV1().Roles(ns).Get(context.TODO(), name, metav1.GetOptions{})
+	role, err := clientset.RbacV1().Roles(ns).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in getting role in %s/%s: %v", ns, name, err)), nil
+		return result.Error(err, "Error in getting role in %s/%s", ns, name)
 	}
 
 	var roleRules []rules
@@ -115,9 +109,5 @@ func GetRole(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolRes
 		Rules: roleRules,
 	}
 
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.JSON(output)
 }
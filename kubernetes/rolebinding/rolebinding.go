@@ -3,8 +3,9 @@ package rolebinding
 import (
 	"fmt"
 	"context"
-	"encoding/json"
 	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/paging"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -16,6 +17,12 @@ type rbData struct {
 	Subjects     []subjects  `json:"subjects,omitempty"`
 }
 
+// Output schemas of the rolebinding tools.
+var (
+	ListOutput = mcp.WithOutputSchema[result.Items[rbData]]()
+	GetOutput  = mcp.WithOutputSchema[rbData]()
+)
+
 type roleRef struct {
 	ApiGroup     string  `json:"apiGroup,omitempty"`
 	Kind         string  `json:"kind,omitempty"`
@@ -33,15 +40,15 @@ func ListRBInNS(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallTool
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for rolebinding")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	rbs, err := clientset.RbacV1().RoleBindings(ns).List(context.TODO(), metav1.ListOptions{})
+	rbs, err := clientset.RbacV1().RoleBindings(ns).List(ctx, paging.Options(request, metav1.ListOptions{}))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing rolebinding in %s: %v", ns, err)), nil
+		return result.Error(err, "Error in listing rolebinding in %s", ns)
 	}
 	var output []rbData
 	for _, rb := range rbs.Items {
@@ -50,61 +57,48 @@ func ListRBInNS(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallTool
 			Namespace: rb.Namespace,
 		})
 	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.Page(output, &rbs.ListMeta)
 }
 
 func ListRB(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	clientset, err := client.InitializeClients()
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
-	}
-	namespaces, err := clientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing namespace: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	var output []rbData
-	for _, namespace := range namespaces.Items {
-		rbs, err := clientset.RbacV1().RoleBindings(namespace.Name).List(context.TODO(), metav1.ListOptions{})
+	return paging.AllNamespaces(ctx, request, clientset, "rolebinding", metav1.ListOptions{}, func(namespace string, opts metav1.ListOptions) ([]rbData, metav1.ListInterface, error) {
+		rbs, err := clientset.RbacV1().RoleBindings(namespace).List(ctx, opts)
 		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Error in listing rolebinding in namespace %s: %v", namespace.Name, err)), nil
+			return nil, nil, err
 		}
-		
+		var output []rbData
 		for _, rb := range rbs.Items {
 			output = append(output, rbData{
 				Name: rb.Name,
 				Namespace: rb.Namespace,
 			})
 		}
-	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+		return output, &rbs.ListMeta, nil
+	})
 }
 
 func GetRB(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for rolebinding")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for rolebinding")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	rb, err := clientset.RbacV1().RoleBindings(ns).Get(context.TODO(), name, metav1.GetOptions{})
+	rb, err := clientset.RbacV1().RoleBindings(ns).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in getting rolebinding in %s/%s: %v", ns, name, err)), nil
+		return result.Error(err, "Error in getting rolebinding in %s/%s", ns, name)
 	}
 
 	var saDetails []subjects
@@ -132,9 +126,5 @@ func GetRB(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResul
 	    Subjects: saDetails,
 	}
 
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.JSON(output)
 }
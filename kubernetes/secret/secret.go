@@ -3,9 +3,11 @@ package secret
 import (
 	"fmt"
 	"context"
-	"encoding/json"
 	"strings"
 	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/paging"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/options"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/api/core/v1"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -17,19 +19,25 @@ type secretData struct {
 	Data      map[string][]byte `json:"data,omitempty"`
 }
 
+// Output schemas of the secret tools.
+var (
+	ListOutput = mcp.WithOutputSchema[result.Items[secretData]]()
+	GetOutput  = mcp.WithOutputSchema[secretData]()
+)
+
 func ListSecretInNS (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for secret")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	secrets, err := clientset.CoreV1().Secrets(ns).List(context.TODO(), metav1.ListOptions{})
+	secrets, err := clientset.CoreV1().Secrets(ns).List(ctx, paging.Options(request, metav1.ListOptions{}))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing secrets in %s: %v", ns, err)), nil
+		return result.Error(err, "Error in listing secrets in %s", ns)
 	}
 	var output []secretData
 	for _, secret := range secrets.Items {
@@ -38,60 +46,48 @@ func ListSecretInNS (ctx context.Context, request mcp.CallToolRequest) (*mcp.Cal
 			Namespace: secret.Namespace,
 		})
 	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.Page(output, &secrets.ListMeta)
 }
 
 func ListSecret (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	namespaces, err := clientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing namespace: %v", err)), nil
-	}
-	var output []secretData
-	for _, namespace := range namespaces.Items {
-		secrets, err := clientset.CoreV1().Secrets(namespace.Name).List(context.TODO(), metav1.ListOptions{})
+	return paging.AllNamespaces(ctx, request, clientset, "secret", metav1.ListOptions{}, func(namespace string, opts metav1.ListOptions) ([]secretData, metav1.ListInterface, error) {
+		secrets, err := clientset.CoreV1().Secrets(namespace).List(ctx, opts)
 		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Error in listing secret in %s: %v", namespace.Name, err)), nil
+			return nil, nil, err
 		}
+		var output []secretData
 		for _, secret := range secrets.Items {
 			output = append(output, secretData{
 				Name: secret.Name,
 				Namespace: secret.Namespace,
 			})
 		}
-	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+		return output, &secrets.ListMeta, nil
+	})
 }
 
 func GetSecret (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for secret")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for secret")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	secret, err := clientset.CoreV1().Secrets(ns).Get(context.TODO(), name, metav1.GetOptions{})
+	secret, err := clientset.CoreV1().Secrets(ns).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in getting secrets in %s: %v", ns, err)), nil
+		return result.Error(err, "Error in getting secrets in %s", ns)
 	}
 	
 	output := secretData{
@@ -100,57 +96,56 @@ func GetSecret (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallTool
 		Data: secret.Data,
 	}
 	
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.JSON(output)
 }
 
 func DeleteSecret (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for secret delete")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for secret delete")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	err = clientset.CoreV1().Secrets(ns).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	err = clientset.CoreV1().Secrets(ns).Delete(ctx, name, options.Delete(request))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in deleting secrets in %s: %v", ns, err)), nil
+		return result.Error(err, "Error in deleting secrets in %s", ns)
 	}
 	
 	output := fmt.Sprintf("Secret %s/%s is deleted", ns, name)
-	return mcp.NewToolResultText(string(output)), nil
+	if options.IsDryRun(request) {
+		output = fmt.Sprintf("Secret %s/%s would be deleted, dry run is enabled", ns, name)
+	}
+	return result.Text(output)
 }
 
 func CreateSecret (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for secret creation")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for secret creation")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	data, err := request.RequireString("data")
 	if err != nil {
 		output := fmt.Sprintf("Provide datas for secret creation like password=kubernetes123,username=kubernetes")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
 
 	secretData := make(map[string]string)
@@ -173,10 +168,13 @@ func CreateSecret (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallT
 		StringData: secretData,
 		Type: v1.SecretTypeOpaque,
 	}
-	createSecret, err := clientset.CoreV1().Secrets(ns).Create(context.TODO(), secret, metav1.CreateOptions{})
+	createSecret, err := clientset.CoreV1().Secrets(ns).Create(ctx, secret, options.Create(request))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in creating secrets in %s/%s: %v", ns, name, err)), nil
+		return result.Error(err, "Error in creating secrets in %s/%s", ns, name)
+	}
+	if options.IsDryRun(request) {
+		return options.DryRunResult(createSecret)
 	}
 	output := fmt.Sprintf("Successfully secret %s/%s is created", createSecret.Namespace, createSecret.Name)
-	return mcp.NewToolResultText(string(output)), nil
+	return result.Text(output)
 }
\ No newline at end of file
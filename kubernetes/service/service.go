@@ -3,10 +3,14 @@ package service
 import (
 	"fmt"
 	"context"
-	"encoding/json"
+	"time"
 	"strings"
 	"strconv"
 	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/paging"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/options"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/view"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -20,86 +24,98 @@ type serviceData struct {
 	InternalIP    string            `json:"internalIP,omitempty"`
 	ExternalIP    string            `json:"externalIP,omitempty"`
 	SelectorLabel map[string]string `json:"selectorLabel,omitempty"`
+	CreationTimestamp string `json:"creationTimestamp,omitempty"`
+}
+
+// Output schemas of the service tools.
+var (
+	ListOutput = mcp.WithOutputSchema[result.Items[serviceData]]()
+	GetOutput  = mcp.WithOutputSchema[serviceData]()
+)
+
+// sortKeys are the sortBy values of the service list tools.
+var sortKeys = map[string]view.Key[serviceData]{
+	"name": view.By(func(service serviceData) string { return service.Namespace + "/" + service.Name }),
+	"creationTimestamp": view.By(func(service serviceData) string { return service.CreationTimestamp }),
 }
 
 func ListServiceInNS (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for service")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	fieldSelector := request.GetString("fieldSelector", "")
+	serviceView, err := view.New(request, sortKeys)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Invalid(err.Error())
 	}
-	services, err := clientset.CoreV1().Services(ns).List(context.TODO(), metav1.ListOptions{})
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing service in %s: %v", ns, err)), nil
+		return result.Error(err, "Error in intialize client")
+	}
+	services, err := clientset.CoreV1().Services(ns).List(ctx, paging.Options(request, metav1.ListOptions{FieldSelector: fieldSelector}))
+	if err != nil {
+		return result.Error(err, "Error in listing service in %s", ns)
 	}
 	var output []serviceData
 	for _, service := range services.Items {
 		output = append(output, serviceData{
 			Name: service.Name,
+			CreationTimestamp: service.CreationTimestamp.UTC().Format(time.RFC3339),
 			Namespace: service.Namespace,
 			Type: string(service.Spec.Type),
 		})
 	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return serviceView.Apply(result.Page(output, &services.ListMeta))
 }
 
 func ListService (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	clientset, err := client.InitializeClients()
+	fieldSelector := request.GetString("fieldSelector", "")
+	serviceView, err := view.New(request, sortKeys)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Invalid(err.Error())
 	}
-	namespaces, err := clientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing namespace: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	var output string
-	for _, namespace := range namespaces.Items {
-		services, err := clientset.CoreV1().Services(namespace.Name).List(context.TODO(), metav1.ListOptions{})
+	return serviceView.Apply(paging.AllNamespaces(ctx, request, clientset, "service", metav1.ListOptions{FieldSelector: fieldSelector}, func(namespace string, opts metav1.ListOptions) ([]serviceData, metav1.ListInterface, error) {
+		services, err := clientset.CoreV1().Services(namespace).List(ctx, opts)
 		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Error in listing service in %s: %v", namespace.Name, err)), nil
+			return nil, nil, err
 		}
 		var output []serviceData
 		for _, service := range services.Items {
 			output = append(output, serviceData{
 				Name: service.Name,
+				CreationTimestamp: service.CreationTimestamp.UTC().Format(time.RFC3339),
 				Namespace: service.Namespace,
 				Type: string(service.Spec.Type),
 			})
 		}
-	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+		return output, &services.ListMeta, nil
+	}))
 }
 
 func GetService (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for service")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for service")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	service, err := clientset.CoreV1().Services(ns).Get(context.TODO(), name, metav1.GetOptions{})
+	service, err := clientset.CoreV1().Services(ns).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in getting service in %s: %v", ns, err)), nil
+		return result.Error(err, "Error in getting service in %s", ns)
 	}
 
 	var externalIP string
@@ -110,6 +126,7 @@ func GetService (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToo
 	
 	output := serviceData{
 		Name: service.Name,
+		CreationTimestamp: service.CreationTimestamp.UTC().Format(time.RFC3339),
 		Namespace: service.Namespace,
 		Type: string(service.Spec.Type),
 		InternalIP: service.Spec.ClusterIP,
@@ -117,57 +134,56 @@ func GetService (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToo
 		SelectorLabel: service.Spec.Selector,
 	}
 	
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.JSON(output)
 }
 
 func DeleteService (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for service")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for service")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	err = clientset.CoreV1().Services(ns).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	err = clientset.CoreV1().Services(ns).Delete(ctx, name, options.Delete(request))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in deleting service in %s/%s: %v", ns, name, err)), nil
+		return result.Error(err, "Error in deleting service in %s/%s", ns, name)
 	}
 	
 	output := fmt.Sprintf("Service %s/%s is deleted", ns, name)
-	return mcp.NewToolResultText(string(output)), nil
+	if options.IsDryRun(request) {
+		output = fmt.Sprintf("Service %s/%s would be deleted, dry run is enabled", ns, name)
+	}
+	return result.Text(output)
 }
 
 func UpdateService (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for service")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for service")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	selectorLabel := request.GetString("selectorLabel", "")
 	svctype := request.GetString("type", "")
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	service, err := clientset.CoreV1().Services(ns).Get(context.TODO(), name, metav1.GetOptions{})
+	service, err := clientset.CoreV1().Services(ns).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in getting service in %s: %v", ns, err)), nil
+		return result.Error(err, "Error in getting service in %s", ns)
 	}
 	if selectorLabel != "" {
 		m := make(map[string]string)
@@ -181,56 +197,62 @@ func UpdateService (ctx context.Context, request mcp.CallToolRequest) (*mcp.Call
 			}
 		}
 		service.Spec.Selector = m
-		updateService, err := clientset.CoreV1().Services(ns).Update(context.TODO(), service, metav1.UpdateOptions{})
+		updateService, err := clientset.CoreV1().Services(ns).Update(ctx, service, options.Update(request))
 		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Error in updating service in %s/%s: %v", ns, name, err)), nil
+			return result.Error(err, "Error in updating service in %s/%s", ns, name)
+		}
+		if options.IsDryRun(request) {
+			return options.DryRunResult(updateService)
 		}
 		output := fmt.Sprintf("Successfully service %s/%s updated with label %s", updateService.Namespace, updateService.Name, selectorLabel)
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Text(output)
 	}
 	if svctype != "" {
 		service.Spec.Type = v1.ServiceType(svctype)
-		updateService, err := clientset.CoreV1().Services(ns).Update(context.TODO(), service, metav1.UpdateOptions{})
+		updateService, err := clientset.CoreV1().Services(ns).Update(ctx, service, options.Update(request))
 		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Error in updating service in %s/%s: %v", ns, name, err)), nil
+			return result.Error(err, "Error in updating service in %s/%s", ns, name)
+		}
+		if options.IsDryRun(request) {
+			return options.DryRunResult(updateService)
 		}
 		output := fmt.Sprintf("Successfully service %s/%s updated with type %s", updateService.Namespace, updateService.Name, svctype)
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Text(output)
 	}
 	output := fmt.Sprintf("Mentioned update in service %s/%s is not possible, we are supporting type and selectorLabelling", ns, name)
-	return mcp.NewToolResultText(string(output)), nil
+	return result.Invalid(output)
 }
 
 func CreateService (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for service")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name,err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for service")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	labels, err := request.RequireString("selectorLabel")
 	if err != nil {
 		output := fmt.Sprintf("Provide selector label for service")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	svcType := request.GetString("svcType", "ClusterIP")
 	svcPort, err := request.RequireString("svcPort")
 	if err != nil {
 		output := fmt.Sprintf("Provide svc port details for service")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	targetPort, err := request.RequireString("targetPort")
 	if err != nil {
 		output := fmt.Sprintf("Provide target port for service")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
 
 	lab := make(map[string]string)
@@ -251,7 +273,7 @@ func CreateService (ctx context.Context, request mcp.CallToolRequest) (*mcp.Call
 	tPorts := strings.Split(targetPort, ",")
 
 	if len(sPorts) != len(tPorts) {
-		return mcp.NewToolResultText("Service ports and target ports counts are not matched"), nil
+		return result.Invalid("Service ports and target ports counts are not matched")
 	}
 
 	for i := range sPorts {
@@ -283,10 +305,13 @@ func CreateService (ctx context.Context, request mcp.CallToolRequest) (*mcp.Call
 			Type: v1.ServiceType(svcType),
 		},
 	}
-	deployService, err := clientset.CoreV1().Services(ns).Create(context.TODO(), service, metav1.CreateOptions{})
+	deployService, err := clientset.CoreV1().Services(ns).Create(ctx, service, options.Create(request))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in creating service in %s/%s: %v", ns, name, err)), nil
+		return result.Error(err, "Error in creating service in %s/%s", ns, name)
+	}
+	if options.IsDryRun(request) {
+		return options.DryRunResult(deployService)
 	}
 	output := fmt.Sprintf("Successfully service %s/%s is created", deployService.Namespace, deployService.Name)
-	return mcp.NewToolResultText(string(output)), nil
+	return result.Text(output)
 }
\ No newline at end of file
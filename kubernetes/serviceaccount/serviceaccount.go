@@ -3,9 +3,11 @@ package serviceaccount
 import (
 	"fmt"
 	"context"
-	"encoding/json"
 	"strings"
 	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/paging"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/options"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -17,23 +19,27 @@ type saData struct {
 	Labels    map[string]string `json:"labels,omitempty"`
 }
 
+// Output schemas of the service account tools.
+var (
+	ListOutput = mcp.WithOutputSchema[result.Items[saData]]()
+	GetOutput  = mcp.WithOutputSchema[saData]()
+)
+
 func ListSAInNS (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for service account")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	labels := request.GetString("label", "")
 
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	sAccount, err := clientset.CoreV1().ServiceAccounts(ns).List(context.TODO(), metav1.ListOptions{
-		LabelSelector: labels,
-	})
+	sAccount, err := clientset.CoreV1().ServiceAccounts(ns).List(ctx, paging.Options(request, metav1.ListOptions{LabelSelector: labels}))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing service accounts in %s: %v", ns, err)), nil
+		return result.Error(err, "Error in listing service accounts in %s", ns)
 	}
 	var output []saData
 	for _, sa := range sAccount.Items {
@@ -43,33 +49,22 @@ func ListSAInNS (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToo
 			Labels: sa.Labels,
 		})
 	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.Page(output, &sAccount.ListMeta)
 }
 
 func ListSA (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	labels := request.GetString("label", "")
 
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	namespaces, err := clientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing namespace: %v", err)), nil
-	}
-	var output []saData
-	for _, namespace := range namespaces.Items {
-		sAccount, err := clientset.CoreV1().ServiceAccounts(namespace.Name).List(context.TODO(), metav1.ListOptions{
-			LabelSelector: labels,
-		})
+	return paging.AllNamespaces(ctx, request, clientset, "service accounts", metav1.ListOptions{LabelSelector: labels}, func(namespace string, opts metav1.ListOptions) ([]saData, metav1.ListInterface, error) {
+		sAccount, err := clientset.CoreV1().ServiceAccounts(namespace).List(ctx, opts)
 		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Error in listing service accounts in %s: %v", namespace.Name, err)), nil
+			return nil, nil, err
 		}
-		
+		var output []saData
 		for _, sa := range sAccount.Items {
 			output = append(output, saData{
 				Name: sa.Name,
@@ -77,33 +72,29 @@ func ListSA (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolRes
 				Labels: sa.Labels,
 			})
 		}
-	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+		return output, &sAccount.ListMeta, nil
+	})
 }
 
 func GetSA (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for service account")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for service account")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
 
-	sAccount, err := clientset.CoreV1().ServiceAccounts(ns).Get(context.TODO(), name, metav1.GetOptions{})
+	sAccount, err := clientset.CoreV1().ServiceAccounts(ns).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in getting service accounts in %s/%s: %v", ns, name, err)), nil
+		return result.Error(err, "Error in getting service accounts in %s/%s", ns, name)
 	}
 	
 	output := saData{
@@ -113,52 +104,51 @@ func GetSA (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResu
 	}
 
 
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.JSON(output)
 }
 
 func DeleteSA (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for service account")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for service account")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
 
-	err = clientset.CoreV1().ServiceAccounts(ns).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	err = clientset.CoreV1().ServiceAccounts(ns).Delete(ctx, name, options.Delete(request))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in deleting service accounts in %s/%s: %v", ns, name, err)), nil
+		return result.Error(err, "Error in deleting service accounts in %s/%s", ns, name)
 	}
 	output := fmt.Sprintf("ServiceAccount %s/%s is deleted", ns, name)
-	return mcp.NewToolResultText(string(output)), nil
+	if options.IsDryRun(request) {
+		output = fmt.Sprintf("ServiceAccount %s/%s would be deleted, dry run is enabled", ns, name)
+	}
+	return result.Text(output)
 }
 
 func CreateSA (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name to create Service account")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace to create Service account")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	labels := request.GetString("label", "")
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
 
 	lab := make(map[string]string)
@@ -182,10 +172,13 @@ func CreateSA (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolR
 		},
 	}
 
-	createServiceAccount, err := clientset.CoreV1().ServiceAccounts(ns).Create(context.TODO(), serviceaccount, metav1.CreateOptions{})
+	createServiceAccount, err := clientset.CoreV1().ServiceAccounts(ns).Create(ctx, serviceaccount, options.Create(request))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in creating service account %s/%s: %v", ns , name, err)), nil
+		return result.Error(err, "Error in creating service account %s/%s", ns , name)
+	}
+	if options.IsDryRun(request) {
+		return options.DryRunResult(createServiceAccount)
 	}
 	output := fmt.Sprintf("Successfully serviceAccount %s/%s is created", createServiceAccount.Namespace, createServiceAccount.Name)
-	return mcp.NewToolResultText(string(output)), nil
+	return result.Text(output)
 }
\ No newline at end of file
@@ -3,9 +3,11 @@ package statefulset
 import (
 	"fmt"
 	"context"
-	"encoding/json"
 	"strings"
 	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/paging"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/options"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/api/core/v1"
 	appsv1 "k8s.io/api/apps/v1"
@@ -23,23 +25,27 @@ type stsData struct{
 	ContainerImage    []string          `json:"containerImage,omitempty"`
 }
 
+// Output schemas of the statefulset tools.
+var (
+	ListOutput = mcp.WithOutputSchema[result.Items[stsData]]()
+	GetOutput  = mcp.WithOutputSchema[stsData]()
+)
+
 func ListStatefulsetInNS (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for statefulset")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	labels := request.GetString("label", "")
 
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	statefulsets, err := clientset.AppsV1().StatefulSets(ns).List(context.TODO(), metav1.ListOptions{
-		LabelSelector: labels,	
-	})
+	statefulsets, err := clientset.AppsV1().StatefulSets(ns).List(ctx, paging.Options(request, metav1.ListOptions{LabelSelector: labels}))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing statefulset in %s: %v", ns, err)), nil
+		return result.Error(err, "Error in listing statefulset in %s", ns)
 	}
 	var output []stsData
 	for _, statefulset := range statefulsets.Items {
@@ -50,32 +56,22 @@ func ListStatefulsetInNS (ctx context.Context, request mcp.CallToolRequest) (*mc
 			Labels: statefulset.Labels,
 		})
 	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.Page(output, &statefulsets.ListMeta)
 }
 
 func ListStatefulset (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	labels := request.GetString("label", "")
 
-	clientset, err := client.InitializeClients()
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
-	}
-	namespaces, err := clientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing namespace: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	var output []stsData
-	for _, namespace := range namespaces.Items {
-		statefulsets, err := clientset.AppsV1().StatefulSets(namespace.Name).List(context.TODO(), metav1.ListOptions{
-			LabelSelector: labels,
-		})
+	return paging.AllNamespaces(ctx, request, clientset, "statefulset", metav1.ListOptions{LabelSelector: labels}, func(namespace string, opts metav1.ListOptions) ([]stsData, metav1.ListInterface, error) {
+		statefulsets, err := clientset.AppsV1().StatefulSets(namespace).List(ctx, opts)
 		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Error in listing statefulset in %s: %v", namespace.Name, err)), nil
+			return nil, nil, err
 		}
+		var output []stsData
 		for _, statefulset := range statefulsets.Items {
 			output = append(output, stsData{
 				Name: statefulset.Name,
@@ -83,33 +79,29 @@ func ListStatefulset (ctx context.Context, request mcp.CallToolRequest) (*mcp.Ca
 				AvailableInstance: fmt.Sprintf(	"%d/%d", statefulset.Status.AvailableReplicas, *statefulset.Spec.Replicas,),
 				Labels: statefulset.Labels,
 			})
-		}	
-	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+		}
+		return output, &statefulsets.ListMeta, nil
+	})
 }
 
 func GetStatefulset (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for statefulset")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide names for statefulset")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	statefulset, err := clientset.AppsV1().StatefulSets(ns).Get(context.TODO(), name, metav1.GetOptions{})
+	statefulset, err := clientset.AppsV1().StatefulSets(ns).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in getting statefulset in %s: %v", ns, err)), nil
+		return result.Error(err, "Error in getting statefulset in %s", ns)
 	}
 
 	var cName []string
@@ -128,60 +120,59 @@ func GetStatefulset (ctx context.Context, request mcp.CallToolRequest) (*mcp.Cal
 		ContainerImage: cImage,
 	}
 
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.JSON(output)
 }
 
 func DeleteStatefulset (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for statefulset")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for statefulset")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	err = clientset.AppsV1().StatefulSets(ns).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	err = clientset.AppsV1().StatefulSets(ns).Delete(ctx, name, options.Delete(request))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in deleting statefulset in %s: %v", ns, err)), nil
+		return result.Error(err, "Error in deleting statefulset in %s", ns)
 	}
 
 	output := fmt.Sprintf("Statefulset %s/%s is deleted", ns, name)
-	return mcp.NewToolResultText(string(output)), nil
+	if options.IsDryRun(request) {
+		output = fmt.Sprintf("Statefulset %s/%s would be deleted, dry run is enabled", ns, name)
+	}
+	return result.Text(output)
 }
 
 func UpdateStatefulset (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for statefulset")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for statefulset")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	labels := request.GetString("label", "")
 	annotation := request.GetString("annotation", "")
 	image := request.GetString("image", "")
 	containerName := request.GetString("containerName", "")
 	replica := request.GetInt("replica", -1)
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	statefulset, err := clientset.AppsV1().StatefulSets(ns).Get(context.TODO(), name, metav1.GetOptions{})
+	statefulset, err := clientset.AppsV1().StatefulSets(ns).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in getting statefulset in %s: %v", ns, err)), nil
+		return result.Error(err, "Error in getting statefulset in %s", ns)
 	}
 	if labels != "" {
 		m := make(map[string]string)
@@ -195,12 +186,15 @@ func UpdateStatefulset (ctx context.Context, request mcp.CallToolRequest) (*mcp.
 			}
 		}
 		statefulset.Labels = m
-		updateStatefulset, err := clientset.AppsV1().StatefulSets(ns).Update(context.TODO(), statefulset, metav1.UpdateOptions{})
+		updateStatefulset, err := clientset.AppsV1().StatefulSets(ns).Update(ctx, statefulset, options.Update(request))
 		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Error in updating statefulset %s/%s with label %s: %v", ns, name, labels, err)), nil
+			return result.Error(err, "Error in updating statefulset %s/%s with label %s", ns, name, labels)
+		}
+		if options.IsDryRun(request) {
+			return options.DryRunResult(updateStatefulset)
 		}
 		output := fmt.Sprintf("Successfully statefulset %s/%s updated with label %s", updateStatefulset.Namespace, updateStatefulset.Name, labels)
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Text(output)
 	}
 	if annotation != "" {
 		m := make(map[string]string)
@@ -214,26 +208,32 @@ func UpdateStatefulset (ctx context.Context, request mcp.CallToolRequest) (*mcp.
 			}
 		}
 		statefulset.Annotations = m
-		updateStatefulset, err := clientset.AppsV1().StatefulSets(ns).Update(context.TODO(), statefulset, metav1.UpdateOptions{})
+		updateStatefulset, err := clientset.AppsV1().StatefulSets(ns).Update(ctx, statefulset, options.Update(request))
 		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Error in updating statefulset  %s/%s with annotation %s: %v", ns, name, annotation, err)), nil
+			return result.Error(err, "Error in updating statefulset  %s/%s with annotation %s", ns, name, annotation)
+		}
+		if options.IsDryRun(request) {
+			return options.DryRunResult(updateStatefulset)
 		}
 		output := fmt.Sprintf("Successfully statefulset %s/%s updated with annotaion %s", updateStatefulset.Namespace, updateStatefulset.Name, annotation)
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Text(output)
 	}
 	if image != "" {
 		if len(statefulset.Spec.Template.Spec.Containers) == 1 {
 			statefulset.Spec.Template.Spec.Containers[0].Image = image
-			updateStatefulset, err := clientset.AppsV1().StatefulSets(ns).Update(context.TODO(), statefulset, metav1.UpdateOptions{})
+			updateStatefulset, err := clientset.AppsV1().StatefulSets(ns).Update(ctx, statefulset, options.Update(request))
 			if err != nil {
-				return mcp.NewToolResultText(fmt.Sprintf("Error in updating statefulset %s/%s with image %s: %v", ns, name, image,  err)), nil
+				return result.Error(err, "Error in updating statefulset %s/%s with image %s", ns, name, image)
+			}
+			if options.IsDryRun(request) {
+				return options.DryRunResult(updateStatefulset)
 			}
 			output := fmt.Sprintf("Successfully statefulset %s/%s updated with image %s", updateStatefulset.Namespace, updateStatefulset.Name, image)
-			return mcp.NewToolResultText(string(output)), nil
+			return result.Text(output)
 		} else {
 			if containerName == "" {
 				output := fmt.Sprintf("Statefulset %s/%s has one than one container please provide the container name to update the image", ns, name)
-				return mcp.NewToolResultText(string(output)), nil
+				return result.Invalid(output)
 			} else {
 				var index int = -1
 				for i, c := range statefulset.Spec.Template.Spec.Containers {
@@ -244,15 +244,18 @@ func UpdateStatefulset (ctx context.Context, request mcp.CallToolRequest) (*mcp.
 				}
 				if index == -1 {
 					output := fmt.Sprintf("Container name %s is not found in statefulset %s/%s ",containerName, ns, name)
-					return mcp.NewToolResultText(string(output)), nil
+					return result.Invalid(output)
 				} else {
 					statefulset.Spec.Template.Spec.Containers[index].Image = image
-					updateStatefulset, err := clientset.AppsV1().StatefulSets(ns).Update(context.TODO(), statefulset, metav1.UpdateOptions{})
+					updateStatefulset, err := clientset.AppsV1().StatefulSets(ns).Update(ctx, statefulset, options.Update(request))
 					if err != nil {
-						return mcp.NewToolResultText(fmt.Sprintf("Error in updating statefulset %s/%s with image %s: %v", ns, name, image, err)), nil
+						return result.Error(err, "Error in updating statefulset %s/%s with image %s", ns, name, image)
+					}
+					if options.IsDryRun(request) {
+						return options.DryRunResult(updateStatefulset)
 					}
 					output := fmt.Sprintf("Successfully statefulset %s/%s updated with image %s", updateStatefulset.Namespace, updateStatefulset.Name, image)
-					return mcp.NewToolResultText(string(output)), nil
+					return result.Text(output)
 				}
 			}
 		}
@@ -260,27 +263,30 @@ func UpdateStatefulset (ctx context.Context, request mcp.CallToolRequest) (*mcp.
 	if replica > -1 {
 		replicas := int32(replica)
 		statefulset.Spec.Replicas = &replicas
-		updateStatefulset, err := clientset.AppsV1().StatefulSets(ns).Update(context.TODO(), statefulset, metav1.UpdateOptions{})
+		updateStatefulset, err := clientset.AppsV1().StatefulSets(ns).Update(ctx, statefulset, options.Update(request))
 		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Error in updating statefulset %s/%s with replica %d: %v", ns, name, replica, err)), nil
+			return result.Error(err, "Error in updating statefulset %s/%s with replica %d", ns, name, replica)
+		}
+		if options.IsDryRun(request) {
+			return options.DryRunResult(updateStatefulset)
 		}
 		output := fmt.Sprintf("Successfully statefulset %s/%s updated with replica %d", updateStatefulset.Namespace, updateStatefulset.Name, replica)
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Text(output)
 	}
 	output := fmt.Sprintf("Mentioned update in statefulset %s/%s is not possible, we are supporting labelling, annotating, replica and image", ns, name)
-	return mcp.NewToolResultText(string(output)), nil
+	return result.Invalid(output)
 }
 
 func CreateStatefulset (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns, err := request.RequireString("namespace")
 	if err != nil {
 		output := fmt.Sprintf("Provide namespace for statefulset")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	name,err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for statefulset")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	labels := request.GetString("label", "")
 	replica := request.GetInt("replica", 1)
@@ -288,25 +294,25 @@ func CreateStatefulset (ctx context.Context, request mcp.CallToolRequest) (*mcp.
 	containerImage, err := request.RequireString("containerImages")
 	if err != nil {
 		output := fmt.Sprintf("Provide image for statefulset")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	containerPort  := request.GetInt("containerPorts", 8080)
 	storageValue, err := request.RequireString("storageValue")
 	if err != nil {
 		output := fmt.Sprintf("Provide storage value for statefulset")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	mountPath, err := request.RequireString("mountPath")
 	if err != nil {
 		output := fmt.Sprintf("Provide mount path for statefulset")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
 	pvcName := request.GetString("pvcName", name)
 	svcPort  := request.GetInt("svcPort", 8080)
 	svcType := request.GetString("svcType", "ClusterIP")
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
 
 	var dsReplica int32
@@ -347,9 +353,12 @@ func CreateStatefulset (ctx context.Context, request mcp.CallToolRequest) (*mcp.
 		},
 	}
 
-	deployService, err := clientset.CoreV1().Services(ns).Create(context.TODO(), service, metav1.CreateOptions{})
+	deployService, err := clientset.CoreV1().Services(ns).Create(ctx, service, options.Create(request))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in creating service for sts in %s/%s: %v", ns, name, err)), nil
+		return result.Error(err, "Error in creating service for sts in %s/%s", ns, name)
+	}
+	if options.IsDryRun(request) {
+		return options.DryRunResult(deployService)
 	}
 
 	statefulset := &appsv1.StatefulSet{
@@ -407,10 +416,13 @@ func CreateStatefulset (ctx context.Context, request mcp.CallToolRequest) (*mcp.
             },
         },
 	}
-	deployStatefulset, err := clientset.AppsV1().StatefulSets(ns).Create(context.TODO(), statefulset, metav1.CreateOptions{})
+	deployStatefulset, err := clientset.AppsV1().StatefulSets(ns).Create(ctx, statefulset, options.Create(request))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in creating statefulset in %s/%s: %v", ns, name, err)), nil
+		return result.Error(err, "Error in creating statefulset in %s/%s", ns, name)
+	}
+	if options.IsDryRun(request) {
+		return options.DryRunResult(deployStatefulset)
 	}
 	output := fmt.Sprintf("Successfully statefulset %s/%s is created with service %s", deployStatefulset.Namespace, deployStatefulset.Name, deployService.Name)
-	return mcp.NewToolResultText(string(output)), nil
+	return result.Text(output)
 }
\ No newline at end of file
@@ -3,8 +3,9 @@ package storageclass
 import (
 	"fmt"
 	"context"
-	"encoding/json"
 	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/paging"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -15,14 +16,20 @@ type scData struct{
 	ReclaimPolicy   string              `json:"reclaimPolicy,omitempty"`
 }
 
+// Output schemas of the storageclass tools.
+var (
+	ListOutput = mcp.WithOutputSchema[result.Items[scData]]()
+	GetOutput  = mcp.WithOutputSchema[scData]()
+)
+
 func ListSC(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	sc, err := clientset.StorageV1().StorageClasses().List(context.TODO(), metav1.ListOptions{})
+	sc, err := clientset.StorageV1().StorageClasses().List(ctx, paging.Options(request, metav1.ListOptions{}))
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in listing storageclass: %v", err)), nil
+		return result.Error(err, "Error in listing storageclass")
 	}
 	var output []scData
 	for _, sclass:= range sc.Items {
@@ -30,26 +37,22 @@ func ListSC(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResu
 			Name: sclass.Name,
 		})
 	}
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.Page(output, &sc.ListMeta)
 }
 
 func GetSC(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	name, err := request.RequireString("name")
 	if err != nil {
 		output := fmt.Sprintf("Provide name for storage class")
-		return mcp.NewToolResultText(string(output)), nil
+		return result.Invalid(output)
 	}
-	clientset, err := client.InitializeClients()
+	clientset, err := client.GetClientset(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in intialize client: %v", err)), nil
+		return result.Error(err, "Error in intialize client")
 	}
-	sc, err := clientset.StorageV1().StorageClasses().Get(context.TODO(), name, metav1.GetOptions{})
+	sc, err := clientset.StorageV1().StorageClasses().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in getting storageclass %s: %v", name, err)), nil
+		return result.Error(err, "Error in getting storageclass %s", name)
 	}
 	output := scData{
 		Name: sc.Name,
@@ -57,9 +60,5 @@ func GetSC(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResul
 		ReclaimPolicy: string(*sc.ReclaimPolicy),	
 	}
 	
-	mcpOutput, err := json.MarshalIndent(output, "", " ")
-	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error in marshalling: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(mcpOutput)), nil
+	return result.JSON(output)
 }
\ No newline at end of file
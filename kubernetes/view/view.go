@@ -0,0 +1,152 @@
+package view
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+)
+
+// Key compares two items for sortBy, it returns a negative number when a
+// sorts before b.
+type Key[T any] func(a, b T) int
+
+// By returns a Key comparing the values get returns.
+func By[T any, V cmp.Ordered](get func(T) V) Key[T] {
+	return func(a, b T) int {
+		return cmp.Compare(get(a), get(b))
+	}
+}
+
+// View is how the items of a list tool are returned: the order set by the
+// sortBy argument and the fields kept by the fields argument.
+type View[T any] struct {
+	key        Key[T]
+	descending bool
+	fields     []string
+}
+
+// New reads the sortBy and fields arguments of request. keys are the sortBy
+// values the tool supports, a leading "-" sorts in descending order. The
+// fields must be JSON fields of T. The fields without omitempty are always
+// kept, since the output schema of the tool requires them.
+func New[T any](request mcp.CallToolRequest, keys map[string]Key[T]) (*View[T], error) {
+	v := &View[T]{}
+	if sortBy := request.GetString("sortBy", ""); sortBy != "" {
+		name := strings.TrimPrefix(sortBy, "-")
+		key, ok := keys[name]
+		if !ok {
+			return nil, fmt.Errorf("Provide sortBy as one of %s, prefixed with - for descending order", strings.Join(names(keys), ", "))
+		}
+		v.key = key
+		v.descending = name != sortBy
+	}
+	if fields := request.GetString("fields", ""); fields != "" {
+		known := jsonFields(reflect.TypeFor[T]())
+		for _, field := range strings.Split(fields, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			if !slices.Contains(known, field) {
+				return nil, fmt.Errorf("Provide fields among %s, unknown field %s", strings.Join(known, ", "), field)
+			}
+			v.fields = append(v.fields, field)
+		}
+		for _, field := range requiredFields(reflect.TypeFor[T]()) {
+			if !slices.Contains(v.fields, field) {
+				v.fields = append(v.fields, field)
+			}
+		}
+	}
+	return v, nil
+}
+
+// Apply sorts the items of the list result res and keeps only the requested
+// fields of each item. The arguments match the results of the list tools, so
+// the call can be wrapped directly. Failed results are returned as is.
+func (v *View[T]) Apply(res *mcp.CallToolResult, err error) (*mcp.CallToolResult, error) {
+	if err != nil || res == nil || res.IsError {
+		return res, err
+	}
+	list, ok := res.StructuredContent.(result.Items[T])
+	if !ok || (v.key == nil && len(v.fields) == 0) {
+		return res, nil
+	}
+	if v.key != nil {
+		sort.SliceStable(list.Items, func(i, j int) bool {
+			if v.descending {
+				return v.key(list.Items[j], list.Items[i]) < 0
+			}
+			return v.key(list.Items[i], list.Items[j]) < 0
+		})
+	}
+	if len(v.fields) == 0 {
+		return result.JSON(list)
+	}
+	projected := result.Items[map[string]json.RawMessage]{
+		Items:              make([]map[string]json.RawMessage, 0, len(list.Items)),
+		Continue:           list.Continue,
+		RemainingItemCount: list.RemainingItemCount,
+		SkippedNamespaces:  list.SkippedNamespaces,
+		OnlyNamespaces:     list.OnlyNamespaces,
+	}
+	for _, item := range list.Items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return result.Error(err, "Error in marshalling")
+		}
+		var all map[string]json.RawMessage
+		if err := json.Unmarshal(data, &all); err != nil {
+			return result.Error(err, "Error in marshalling")
+		}
+		kept := map[string]json.RawMessage{}
+		for _, field := range v.fields {
+			if value, ok := all[field]; ok {
+				kept[field] = value
+			}
+		}
+		projected.Items = append(projected.Items, kept)
+	}
+	return result.JSON(projected)
+}
+
+func names[T any](keys map[string]Key[T]) []string {
+	list := make([]string, 0, len(keys))
+	for name := range keys {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// requiredFields returns the JSON names of the fields of the struct t that
+// have no omitempty, the output schema requires them.
+func requiredFields(t reflect.Type) []string {
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		name, options, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" && !slices.Contains(strings.Split(options, ","), "omitempty") {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// jsonFields returns the JSON names of the fields of the struct t.
+func jsonFields(t reflect.Type) []string {
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
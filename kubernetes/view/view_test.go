@@ -0,0 +1,130 @@
+package view
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+)
+
+type item struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	Restarts  int    `json:"restarts"`
+	Node      string `json:"node,omitempty"`
+	Internal  string `json:"-"`
+}
+
+var keys = map[string]Key[item]{
+	"name":     By(func(i item) string { return i.Name }),
+	"restarts": By(func(i item) int { return i.Restarts }),
+}
+
+func request(arguments map[string]any) mcp.CallToolRequest {
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = arguments
+	return request
+}
+
+func TestRequiredFields(t *testing.T) {
+	want := []string{"name", "restarts"}
+	if got := requiredFields(reflect.TypeFor[item]()); !slices.Equal(got, want) {
+		t.Errorf("requiredFields(item) = %q, want %q", got, want)
+	}
+	want = []string{"name", "namespace", "restarts", "node"}
+	if got := jsonFields(reflect.TypeFor[item]()); !slices.Equal(got, want) {
+		t.Errorf("jsonFields(item) = %q, want %q", got, want)
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		sortBy, fields string
+		wantErr        bool
+		wantFields     []string
+	}{
+		{"", "", false, nil},
+		{"name", "", false, nil},
+		{"-restarts", "", false, nil},
+		{"node", "", true, nil},
+		{"--name", "", true, nil},
+		{"", "node", false, []string{"node", "name", "restarts"}},
+		{"", " namespace, ,name ", false, []string{"namespace", "name", "restarts"}},
+		{"", "Internal", true, nil},
+	}
+	for _, test := range tests {
+		v, err := New(request(map[string]any{"sortBy": test.sortBy, "fields": test.fields}), keys)
+		if (err != nil) != test.wantErr {
+			t.Errorf("New(%q, %q) error = %v, want error %v", test.sortBy, test.fields, err, test.wantErr)
+			continue
+		}
+		if err == nil && !slices.Equal(v.fields, test.wantFields) {
+			t.Errorf("New(%q, %q) fields = %q, want %q", test.sortBy, test.fields, v.fields, test.wantFields)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	items := []item{
+		{Name: "b", Namespace: "default", Restarts: 2, Node: "n1"},
+		{Name: "c", Namespace: "kube-system", Restarts: 0},
+		{Name: "a", Namespace: "default", Restarts: 2, Node: "n2"},
+	}
+	tests := []struct {
+		sortBy, fields string
+		want           string
+	}{
+		{"", "", `[{"name":"b","namespace":"default","restarts":2,"node":"n1"},{"name":"c","namespace":"kube-system","restarts":0},{"name":"a","namespace":"default","restarts":2,"node":"n2"}]`},
+		{"name", "", `[{"name":"a","namespace":"default","restarts":2,"node":"n2"},{"name":"b","namespace":"default","restarts":2,"node":"n1"},{"name":"c","namespace":"kube-system","restarts":0}]`},
+		{"-name", "name", `[{"name":"c","restarts":0},{"name":"b","restarts":2},{"name":"a","restarts":2}]`},
+		// Equal keys keep the list order, in both directions.
+		{"restarts", "name", `[{"name":"c","restarts":0},{"name":"b","restarts":2},{"name":"a","restarts":2}]`},
+		{"-restarts", "name", `[{"name":"b","restarts":2},{"name":"a","restarts":2},{"name":"c","restarts":0}]`},
+		// An empty omitempty field is left out rather than returned as null.
+		{"", "node", `[{"name":"b","node":"n1","restarts":2},{"name":"c","restarts":0},{"name":"a","node":"n2","restarts":2}]`},
+	}
+	for _, test := range tests {
+		v, err := New(request(map[string]any{"sortBy": test.sortBy, "fields": test.fields}), keys)
+		if err != nil {
+			t.Fatalf("New(%q, %q): %v", test.sortBy, test.fields, err)
+		}
+		list := result.Items[item]{Items: slices.Clone(items), Continue: "next", OnlyNamespaces: []string{"default"}}
+		res, err := v.Apply(result.JSON(list))
+		if err != nil || res.IsError {
+			t.Errorf("Apply(%q, %q) failed: %v %v", test.sortBy, test.fields, err, res.Content)
+			continue
+		}
+		data, err := json.Marshal(res.StructuredContent)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got struct {
+			Items          json.RawMessage `json:"items"`
+			Continue       string          `json:"continue"`
+			OnlyNamespaces []string        `json:"onlyNamespaces"`
+		}
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatal(err)
+		}
+		if string(got.Items) != test.want {
+			t.Errorf("Apply(%q, %q) = %s, want %s", test.sortBy, test.fields, got.Items, test.want)
+		}
+		if got.Continue != "next" || !slices.Equal(got.OnlyNamespaces, []string{"default"}) {
+			t.Errorf("Apply(%q, %q) lost the list metadata: %s", test.sortBy, test.fields, data)
+		}
+	}
+}
+
+func TestApplyFailed(t *testing.T) {
+	v, err := New(request(map[string]any{"sortBy": "name", "fields": "name"}), keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	failed, _ := result.Invalid("Provide a name")
+	if res, err := v.Apply(failed, nil); res != failed || err != nil {
+		t.Errorf("Apply(failed) = %v, %v, want the failed result", res, err)
+	}
+}
@@ -1,10 +1,17 @@
 package main
 
 import (
-	"fmt"
 	"flag"
+	"log"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/naveenthangaraj03/k8s-mcp-server/tools"
+	"github.com/naveenthangaraj03/k8s-mcp-server/policy"
+	"github.com/naveenthangaraj03/k8s-mcp-server/audit"
+	"github.com/naveenthangaraj03/k8s-mcp-server/transport"
+	"github.com/naveenthangaraj03/k8s-mcp-server/timeout"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/client"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/cache"
 	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/pod"
 	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/namespace"
 	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/deployment"
@@ -22,119 +29,182 @@ import (
 	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/clusterrole"
 	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/clusterrolebinding"
 	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/storageclass"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/cluster"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/manifest"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/resource"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/apiresource"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/forward"
 )
 
 
 func main() {
+	flag.Parse()
+
+	serverOptions := []server.ServerOption{server.WithToolHandlerMiddleware(timeout.Middleware), server.WithLogging()}
+	auditLog, err := audit.Open(transport.Name() == transport.Stdio)
+	if err != nil {
+		log.Fatalf("audit: %v", err)
+	}
+	if auditLog != nil {
+		defer auditLog.Close()
+		serverOptions = append(serverOptions, server.WithToolHandlerMiddleware(auditLog.Middleware))
+	}
+
 	s := server.NewMCPServer(
 		"Kubernetes MCP",
         "1.0.0",
+		serverOptions...,
 	)
 
-	flag.Parse()
-
-	s.AddTool(tools.ListPodInNamespace, pod.ListPodInNS)
-	s.AddTool(tools.ListPod, pod.ListPod)
-	s.AddTool(tools.GetPod, pod.GetPod)
-	s.AddTool(tools.DeletePod, pod.DeletePod)
-	s.AddTool(tools.UpdatePod, pod.UpdatePod)
-	s.AddTool(tools.CreatePod, pod.CreatePod)
-	s.AddTool(tools.PodLog, pod.PodLog)
-
-
-	s.AddTool(tools.ListNS, namespace.ListNS)
-	s.AddTool(tools.GetNS, namespace.GetNS)
-	s.AddTool(tools.DeleteNS, namespace.DeleteNS)
-	s.AddTool(tools.UpdateNS, namespace.UpdateNS)
-	s.AddTool(tools.CreateNS, namespace.CreateNS)
-
-
-	s.AddTool(tools.ListNode, node.ListNode)
-	s.AddTool(tools.GetNode, node.GetNode)
-	s.AddTool(tools.DeleteNode, node.DeleteNode)
-	s.AddTool(tools.UpdateNode, node.UpdateNode)
-
-
-	s.AddTool(tools.ListDeploymentInNamespace, deployment.ListDeploymentInNS)
-	s.AddTool(tools.ListDeployment, deployment.ListDeployment)
-	s.AddTool(tools.GetDeployment, deployment.GetDeployment)
-	s.AddTool(tools.DeleteDeployment, deployment.DeleteDeployment)
-	s.AddTool(tools.CreateDeployment, deployment.CreateDeployment)
-	s.AddTool(tools.UpdateDeployment, deployment.UpdateDeployment)
-
-
-	s.AddTool(tools.ListDaemonsetInNamespace, daemonset.ListDaemonsetInNS)
-	s.AddTool(tools.ListDaemonset, daemonset.ListDaemonset)
-	s.AddTool(tools.GetDaemonset, daemonset.GetDaemonset)
-	s.AddTool(tools.DeleteDaemonset, daemonset.DeleteDaemonset)
-	s.AddTool(tools.UpdateDaemonset, daemonset.UpdateDaemonset)
-	s.AddTool(tools.CreateDaemonset, daemonset.CreateDaemonset)
-
-
-	s.AddTool(tools.ListStatefulsetInNamespace, statefulset.ListStatefulsetInNS)
-	s.AddTool(tools.ListStatefulset, statefulset.ListStatefulset)
-	s.AddTool(tools.GetStatefulset, statefulset.GetStatefulset)
-	s.AddTool(tools.DeleteStatefulset, statefulset.DeleteStatefulset)
-	s.AddTool(tools.UpdateStatefulset, statefulset.UpdateStatefulset)
-	s.AddTool(tools.CreateStatefulset, statefulset.CreateStatefulset)
-
+	// Resolve the credentials up front so the chosen auth source, or the
+	// reason none was found, shows up in the server log at startup.
+	if _, err := client.KubeConfig(); err != nil {
+		log.Printf("kubernetes: %v", err)
+	}
+
+	toolPolicy, err := policy.Load()
+	if err != nil {
+		log.Fatalf("policy: %v", err)
+	}
+	if toolPolicy.ReadOnly() {
+		log.Printf("policy: read-only mode, mutating tools are not registered")
+	}
+	addTool := func(tool mcp.Tool, handler server.ToolHandlerFunc) {
+		if !toolPolicy.Allowed(tool) {
+			log.Printf("policy: tool %s is not registered", tool.Name)
+			return
+		}
+		if cache.Enabled() && policy.IsReadOnly(tool) {
+			handler = cache.Middleware(handler)
+		}
+		s.AddTool(tool, handler)
+	}
+
+	addTool(tools.ListPodInNamespace, pod.ListPodInNS)
+	addTool(tools.ListPod, pod.ListPod)
+	addTool(tools.GetPod, pod.GetPod)
+	addTool(tools.DeletePod, pod.DeletePod)
+	addTool(tools.UpdatePod, pod.UpdatePod)
+	addTool(tools.CreatePod, pod.CreatePod)
+	addTool(tools.PodLog, pod.PodLog)
+	addTool(tools.WorkloadLogs, pod.WorkloadLogs)
+	addTool(tools.ExecPod, pod.ExecPod(toolPolicy.ExecAllowed))
+	addTool(tools.CopyFromPod, pod.CopyFromPod(toolPolicy.CopyAllowed))
+	addTool(tools.CopyToPod, pod.CopyToPod(toolPolicy.CopyAllowed))
+	addTool(tools.DebugPod, pod.DebugPod(toolPolicy.DebugAllowed, toolPolicy.ExecAllowed))
+
+
+	addTool(tools.ListNS, namespace.ListNS)
+	addTool(tools.GetNS, namespace.GetNS)
+	addTool(tools.DeleteNS, namespace.DeleteNS)
+	addTool(tools.UpdateNS, namespace.UpdateNS)
+	addTool(tools.CreateNS, namespace.CreateNS)
+
+
+	addTool(tools.ListNode, node.ListNode)
+	addTool(tools.GetNode, node.GetNode)
+	addTool(tools.DeleteNode, node.DeleteNode)
+	addTool(tools.UpdateNode, node.UpdateNode)
+
+
+	addTool(tools.ListDeploymentInNamespace, deployment.ListDeploymentInNS)
+	addTool(tools.ListDeployment, deployment.ListDeployment)
+	addTool(tools.GetDeployment, deployment.GetDeployment)
+	addTool(tools.DeleteDeployment, deployment.DeleteDeployment)
+	addTool(tools.CreateDeployment, deployment.CreateDeployment)
+	addTool(tools.UpdateDeployment, deployment.UpdateDeployment)
+
+
+	addTool(tools.ListDaemonsetInNamespace, daemonset.ListDaemonsetInNS)
+	addTool(tools.ListDaemonset, daemonset.ListDaemonset)
+	addTool(tools.GetDaemonset, daemonset.GetDaemonset)
+	addTool(tools.DeleteDaemonset, daemonset.DeleteDaemonset)
+	addTool(tools.UpdateDaemonset, daemonset.UpdateDaemonset)
+	addTool(tools.CreateDaemonset, daemonset.CreateDaemonset)
+
+
+	addTool(tools.ListStatefulsetInNamespace, statefulset.ListStatefulsetInNS)
+	addTool(tools.ListStatefulset, statefulset.ListStatefulset)
+	addTool(tools.GetStatefulset, statefulset.GetStatefulset)
+	addTool(tools.DeleteStatefulset, statefulset.DeleteStatefulset)
+	addTool(tools.UpdateStatefulset, statefulset.UpdateStatefulset)
+	addTool(tools.CreateStatefulset, statefulset.CreateStatefulset)
+
+
+	addTool(tools.ListServiceInNamespace, service.ListServiceInNS)
+	addTool(tools.ListService, service.ListService)
+	addTool(tools.GetService, service.GetService)
+	addTool(tools.DeleteService, service.DeleteService)
+	addTool(tools.UpdateService, service.UpdateService)
+	addTool(tools.CreateService, service.CreateService)
+
+
+	addTool(tools.ListConfigmapInNamespace, configmap.ListConfigmapInNS)
+	addTool(tools.ListConfigmap, configmap.ListConfigmap)
+	addTool(tools.GetConfigmap, configmap.GetConfigmap)
+	addTool(tools.DeleteConfigmap, configmap.DeleteConfigmap)
+	addTool(tools.CreateConfigmap, configmap.CreateConfigmap)
+
+
+	addTool(tools.ListSecretInNamespace, secret.ListSecretInNS)
+	addTool(tools.ListSecret, secret.ListSecret)
+	addTool(tools.GetSecret, secret.GetSecret)
+	addTool(tools.DeleteSecret, secret.DeleteSecret)
+	addTool(tools.CreateSecret, secret.CreateSecret)
+	
+	
+	addTool(tools.ListSA, serviceaccount.ListSA)
+	addTool(tools.ListSAInNS, serviceaccount.ListSAInNS)
+	addTool(tools.GetSA, serviceaccount.GetSA)
+	addTool(tools.DeleteSA, serviceaccount.DeleteSA)
+	addTool(tools.CreateSA, serviceaccount.CreateSA)
+
+	addTool(tools.ListRole, role.ListRole)
+	addTool(tools.ListRoleInNS, role.ListRoleInNS)
+	addTool(tools.GetRole, role.GetRole)
+	
+	addTool(tools.ListRB, rolebinding.ListRB)
+	addTool(tools.ListRBInNS, rolebinding.ListRBInNS)
+	addTool(tools.GetRB, rolebinding.GetRB)
 
-	s.AddTool(tools.ListServiceInNamespace, service.ListServiceInNS)
-	s.AddTool(tools.ListService, service.ListService)
-	s.AddTool(tools.GetService, service.GetService)
-	s.AddTool(tools.DeleteService, service.GetService)
-	s.AddTool(tools.UpdateService, service.UpdateService)
-	s.AddTool(tools.CreateService, service.CreateService)
+	addTool(tools.ListPVC, pvc.ListPVC)
+	addTool(tools.ListPVCInNS, pvc.ListPVCInNS)
+	addTool(tools.GetPVC, pvc.GetPVC)
+	addTool(tools.DeletePVC, pvc.DeletePVC)
+	addTool(tools.UpdatePVC, pvc.UpdatePVC)
 
+	addTool(tools.ListPV, pv.ListPV)
+	addTool(tools.GetPV, pv.GetPV)
+	addTool(tools.DeletePV, pv.DeletePV)
 
-	s.AddTool(tools.ListConfigmapInNamespace, configmap.ListConfigmapInNS)
-	s.AddTool(tools.ListConfigmap, configmap.ListConfigmap)
-	s.AddTool(tools.GetConfigmap, configmap.GetConfigmap)
-	s.AddTool(tools.DeleteConfigmap, configmap.DeleteConfigmap)
-	s.AddTool(tools.CreateConfigmap, configmap.CreateConfigmap)
+	addTool(tools.ListCR, clusterrole.ListCR)
+	addTool(tools.GetCR, clusterrole.GetCR)
 
+	addTool(tools.ListCRB, clusterrolebinding.ListCRB)
+	addTool(tools.GetCRB, clusterrolebinding.GetCRB)
 
-	s.AddTool(tools.ListSecretInNamespace, secret.ListSecretInNS)
-	s.AddTool(tools.ListSecret, secret.ListSecret)
-	s.AddTool(tools.GetSecret, secret.GetSecret)
-	s.AddTool(tools.DeleteSecret, secret.DeleteSecret)
-	s.AddTool(tools.CreateSecret, secret.CreateSecret)
-	
-	
-	s.AddTool(tools.ListSA, serviceaccount.ListSA)
-	s.AddTool(tools.ListSAInNS, serviceaccount.ListSAInNS)
-	s.AddTool(tools.GetSA, serviceaccount.GetSA)
-	s.AddTool(tools.DeleteSA, serviceaccount.DeleteSA)
-	s.AddTool(tools.CreateSA, serviceaccount.CreateSA)
-
-	s.AddTool(tools.ListRole, role.ListRole)
-	s.AddTool(tools.ListRoleInNS, role.ListRoleInNS)
-	s.AddTool(tools.GetRole, role.GetRole)
-	
-	s.AddTool(tools.ListRB, rolebinding.ListRB)
-	s.AddTool(tools.ListRBInNS, rolebinding.ListRBInNS)
-	s.AddTool(tools.GetRB, rolebinding.GetRB)
+	addTool(tools.ListSC, storageclass.ListSC)
+	addTool(tools.GetSC, storageclass.GetSC)
 
-	s.AddTool(tools.ListPVC, pvc.ListPVC)
-	s.AddTool(tools.ListPVCInNS, pvc.ListPVCInNS)
-	s.AddTool(tools.GetPVC, pvc.GetPVC)
-	s.AddTool(tools.DeletePVC, pvc.DeletePVC)
-	s.AddTool(tools.UpdatePVC, pvc.UpdatePVC)
+	addTool(tools.ListCluster, cluster.ListCluster)
 
-	s.AddTool(tools.ListPV, pv.ListPV)
-	s.AddTool(tools.GetPV, pv.GetPV)
-	s.AddTool(tools.DeletePV, pv.DeletePV)
+	addTool(tools.ApplyManifest, manifest.ApplyManifest)
 
-	s.AddTool(tools.ListCR, clusterrole.ListCR)
-	s.AddTool(tools.GetCR, clusterrole.GetCR)
+	addTool(tools.ListResource, resource.ListResource)
+	addTool(tools.GetResource, resource.GetResource)
+	addTool(tools.DeleteResource, resource.DeleteResource)
+	addTool(tools.PatchResource, resource.PatchResource)
 
-	s.AddTool(tools.ListCRB, clusterrolebinding.ListCRB)
-	s.AddTool(tools.GetCRB, clusterrolebinding.GetCRB)
+	addTool(tools.APIResources, apiresource.ListAPIResources)
+	addTool(tools.Explain, apiresource.Explain)
 
-	s.AddTool(tools.ListSC, storageclass.ListSC)
-	s.AddTool(tools.GetSC, storageclass.GetSC)
+	addTool(tools.StartPortForward, forward.StartPortForward)
+	addTool(tools.ListPortForwards, forward.ListPortForwards)
+	addTool(tools.StopPortForward, forward.StopPortForward)
 
-    if err := server.ServeStdio(s); err != nil {
-        fmt.Printf("Error starting server: %v\n", err)
+    err = transport.Serve(s)
+    forward.StopAll()
+    if err != nil {
+        log.Fatalf("Error starting server: %v", err)
     }
 }
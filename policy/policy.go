@@ -0,0 +1,199 @@
+package policy
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"sigs.k8s.io/yaml"
+)
+
+var readOnly bool
+var configPath string
+
+func init() {
+	flag.BoolVar(&readOnly, "readOnly", false, "Only register tools that do not modify the cluster")
+	flag.StringVar(&configPath, "toolConfig", "", "Path to a YAML or JSON file with the readOnly, allow, deny, exec, copy and debug lists")
+}
+
+// Config is the tool policy file. Allow and Deny take tool names or glob
+// patterns like "delete-*". Exec lists the commands exec-pod may run, see
+// ExecAllowed, Copy the container paths the copy tools may use and Debug the
+// images debug-pod may start, see DebugAllowed.
+type Config struct {
+	ReadOnly bool      `json:"readOnly,omitempty"`
+	Allow    []string  `json:"allow,omitempty"`
+	Deny     []string  `json:"deny,omitempty"`
+	Exec     []string  `json:"exec,omitempty"`
+	Copy     CopyPaths `json:"copy,omitempty"`
+	Debug    []string  `json:"debug,omitempty"`
+}
+
+// CopyPaths are the container paths copy-from-pod may read and copy-to-pod
+// may write, see CopyAllowed.
+type CopyPaths struct {
+	Read  []string `json:"read,omitempty"`
+	Write []string `json:"write,omitempty"`
+}
+
+// Policy decides which tools the server registers.
+type Policy struct {
+	config Config
+}
+
+// Load builds the policy from the --readOnly and --toolConfig flags. The
+// server is read-only when either the flag or the file says so.
+func Load() (*Policy, error) {
+	var config Config
+	if configPath != "" {
+		data, err := os.ReadFile(configPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading tool config %s: %w", configPath, err)
+		}
+		if err := yaml.UnmarshalStrict(data, &config); err != nil {
+			return nil, fmt.Errorf("parsing tool config %s: %w", configPath, err)
+		}
+		for _, pattern := range append(config.Allow, config.Deny...) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid tool pattern %q in %s: %w", pattern, configPath, err)
+			}
+		}
+		for _, command := range config.Exec {
+			for _, word := range strings.Fields(command) {
+				if _, err := path.Match(word, ""); err != nil {
+					return nil, fmt.Errorf("invalid exec command %q in %s: %w", command, configPath, err)
+				}
+			}
+		}
+		for _, pattern := range config.Debug {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid debug image %q in %s: %w", pattern, configPath, err)
+			}
+		}
+		for _, pattern := range append(config.Copy.Read, config.Copy.Write...) {
+			if !path.IsAbs(pattern) {
+				return nil, fmt.Errorf("copy path %q in %s is not absolute", pattern, configPath)
+			}
+			if _, err := path.Match(strings.TrimSuffix(pattern, "/**"), ""); err != nil {
+				return nil, fmt.Errorf("invalid copy path %q in %s: %w", pattern, configPath, err)
+			}
+		}
+	}
+	config.ReadOnly = config.ReadOnly || readOnly
+	return &Policy{config: config}, nil
+}
+
+// ReadOnly reports whether mutating tools are refused.
+func (p *Policy) ReadOnly() bool {
+	return p.config.ReadOnly
+}
+
+// Allowed reports whether the tool may be registered. The deny list wins over
+// the allow list, and read-only mode refuses every tool not annotated as
+// read-only even when it is allowed explicitly.
+func (p *Policy) Allowed(tool mcp.Tool) bool {
+	if matchAny(p.config.Deny, tool.Name) {
+		return false
+	}
+	if len(p.config.Allow) > 0 && !matchAny(p.config.Allow, tool.Name) {
+		return false
+	}
+	if p.config.ReadOnly && !IsReadOnly(tool) {
+		return false
+	}
+	return true
+}
+
+// ExecAllowed reports whether exec-pod may run command. Each entry of the
+// exec list is a command with its arguments separated by spaces, and command
+// must match one entry argument by argument. An argument of an entry may be
+// a glob pattern like "/var/log/*", and a last argument of "**" matches any
+// remaining arguments. Nothing is allowed when the list is empty.
+func (p *Policy) ExecAllowed(command []string) bool {
+	for _, entry := range p.config.Exec {
+		if matchCommand(strings.Fields(entry), command) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchCommand(patterns, command []string) bool {
+	for i, pattern := range patterns {
+		if pattern == "**" && i == len(patterns)-1 {
+			return true
+		}
+		if i >= len(command) {
+			return false
+		}
+		if ok, _ := path.Match(pattern, command[i]); !ok {
+			return false
+		}
+	}
+	return len(patterns) == len(command) && len(patterns) > 0
+}
+
+// CopyAllowed reports whether the copy tools may read, or write when write
+// is set, the absolute container path name. The copy paths are glob patterns
+// like "/tmp/*.log", and a pattern ending with "/**" matches a directory and
+// everything below it. recursive is set when name is a directory to copy
+// with everything below it, only "/**" patterns allow that. Nothing is
+// allowed when the list is empty.
+func (p *Policy) CopyAllowed(name string, write, recursive bool) bool {
+	patterns := p.config.Copy.Read
+	if write {
+		patterns = p.config.Copy.Write
+	}
+	for _, pattern := range patterns {
+		if recursive && !strings.HasSuffix(pattern, "/**") {
+			continue
+		}
+		if matchPath(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchPath(pattern, name string) bool {
+	dir, recursive := strings.CutSuffix(pattern, "/**")
+	if !recursive {
+		ok, _ := path.Match(pattern, name)
+		return ok
+	}
+	if dir == "" {
+		dir = "/"
+	}
+	for ; ; name = path.Dir(name) {
+		if ok, _ := path.Match(dir, name); ok {
+			return true
+		}
+		if name == "/" {
+			return false
+		}
+	}
+}
+
+// DebugAllowed reports whether debug-pod may start a debug container with
+// image. The debug images are glob patterns like "busybox:*" or
+// "registry.example.com/debug/*". Nothing is allowed when the list is empty.
+func (p *Policy) DebugAllowed(image string) bool {
+	return matchAny(p.config.Debug, image)
+}
+
+// IsReadOnly reports whether the tool is annotated as not modifying the cluster.
+func IsReadOnly(tool mcp.Tool) bool {
+	return tool.Annotations.ReadOnlyHint != nil && *tool.Annotations.ReadOnlyHint
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMatchCommand(t *testing.T) {
+	tests := []struct {
+		pattern string
+		command []string
+		want    bool
+	}{
+		{"ps aux", []string{"ps", "aux"}, true},
+		{"ps aux", []string{"ps"}, false},
+		{"ps aux", []string{"ps", "aux", "-w"}, false},
+		{"ps", []string{"ps", "aux"}, false},
+		{"cat /var/log/*", []string{"cat", "/var/log/app.log"}, true},
+		{"cat /var/log/*", []string{"cat", "/var/log/app/x.log"}, false},
+		{"cat /var/log/*", []string{"cat", "/etc/shadow"}, false},
+		{"ls **", []string{"ls"}, true},
+		{"ls **", []string{"ls", "-la", "/tmp"}, true},
+		{"ls **", []string{"cat", "/tmp"}, false},
+		{"** ls", []string{"rm", "ls"}, true},
+		{"** ls", []string{"rm", "-rf", "ls"}, false},
+		{"", []string{}, false},
+		{"", nil, false},
+	}
+	for _, test := range tests {
+		if got := matchCommand(strings.Fields(test.pattern), test.command); got != test.want {
+			t.Errorf("matchCommand(%q, %q) = %v, want %v", test.pattern, test.command, got, test.want)
+		}
+	}
+}
+
+func TestMatchPath(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"/etc/nginx/nginx.conf", "/etc/nginx/nginx.conf", true},
+		{"/etc/nginx/nginx.conf", "/etc/nginx", false},
+		{"/tmp/*.conf", "/tmp/app.conf", true},
+		{"/tmp/*.conf", "/tmp/sub/app.conf", false},
+		{"/tmp/*.conf", "/tmp", false},
+		{"/etc/app/*", "/etc/app/config", true},
+		{"/etc/app/*", "/etc/app/sub/config", false},
+		{"/etc/nginx/**", "/etc/nginx", true},
+		{"/etc/nginx/**", "/etc/nginx/conf.d/default.conf", true},
+		{"/etc/nginx/**", "/etc/nginx-other/x", false},
+		{"/etc/nginx/**", "/etc", false},
+		{"/etc/nginx/**", "/", false},
+		{"/var/log/*/**", "/var/log/app/x/y.log", true},
+		{"/var/log/*/**", "/var/log", false},
+		{"/**", "/", true},
+		{"/**", "/etc/shadow", true},
+	}
+	for _, test := range tests {
+		if got := matchPath(test.pattern, test.name); got != test.want {
+			t.Errorf("matchPath(%q, %q) = %v, want %v", test.pattern, test.name, got, test.want)
+		}
+	}
+}
+
+func TestCopyAllowed(t *testing.T) {
+	p := &Policy{config: Config{Copy: CopyPaths{
+		Read:  []string{"/etc/app/*", "/etc/nginx/**"},
+		Write: []string{"/tmp/*.conf"},
+	}}}
+	tests := []struct {
+		name             string
+		write, recursive bool
+		want             bool
+	}{
+		{"/etc/app/config", false, false, true},
+		{"/etc/app/config", false, true, false},
+		{"/etc/nginx/conf.d", false, true, true},
+		{"/etc/nginx/conf.d/default.conf", false, false, true},
+		{"/etc/app/config", true, false, false},
+		{"/tmp/app.conf", true, false, true},
+		{"/tmp/app.conf", false, false, false},
+	}
+	for _, test := range tests {
+		if got := p.CopyAllowed(test.name, test.write, test.recursive); got != test.want {
+			t.Errorf("CopyAllowed(%q, %v, %v) = %v, want %v", test.name, test.write, test.recursive, got, test.want)
+		}
+	}
+}
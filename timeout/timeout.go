@@ -0,0 +1,49 @@
+package timeout
+
+import (
+	"context"
+	"flag"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+var defaultTimeout time.Duration
+
+func init() {
+	flag.DurationVar(&defaultTimeout, "timeout", 30*time.Second, "Time limit of a tool call when it does not pass timeoutSeconds, 0 disables it")
+}
+
+const (
+	// DefaultFollowSeconds is how long a call with follow keeps following
+	// when it does not pass followSeconds.
+	DefaultFollowSeconds = 30
+	// followGrace is the time a call with follow gets on top of followSeconds
+	// to start the stream and return its result.
+	followGrace = 10 * time.Second
+)
+
+// Middleware bounds every tool call by its timeoutSeconds argument, or by
+// --timeout when it is not set. Without timeoutSeconds, a call with follow is
+// given at least its followSeconds plus a grace period, so the follow is not
+// cut short by --timeout. The handler context is cancelled when the
+// limit is reached or when the client cancels the request, which aborts the
+// calls to the API server.
+func Middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		limit := defaultTimeout
+		if seconds := request.GetFloat("timeoutSeconds", 0); seconds > 0 {
+			limit = time.Duration(seconds * float64(time.Second))
+		} else if limit > 0 && request.GetBool("follow", false) {
+			follow := time.Duration(request.GetFloat("followSeconds", DefaultFollowSeconds)*float64(time.Second)) + followGrace
+			limit = max(limit, follow)
+		}
+		if limit > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, limit)
+			defer cancel()
+		}
+		return next(ctx, request)
+	}
+}
@@ -1,8 +1,136 @@
 package tools
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/mark3labs/mcp-go/mcp"
-)
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/apiresource"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/cluster"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/clusterrole"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/clusterrolebinding"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/configmap"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/confirm"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/daemonset"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/deployment"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/forward"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/manifest"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/namespace"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/node"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/pod"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/pv"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/pvc"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/resource"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/result"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/role"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/rolebinding"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/secret"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/service"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/serviceaccount"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/statefulset"
+	"github.com/naveenthangaraj03/k8s-mcp-server/kubernetes/storageclass"
+)
+
+// withCluster adds the optional arguments every tool accepts to pick the
+// kubeconfig context the call runs against and its time limit.
+func withCluster() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString(
+			"context",
+			mcp.Description("The kubeconfig context to run against, defaults to the current context"),
+		)(tool)
+		mcp.WithString(
+			"cluster",
+			mcp.Description("The kubeconfig cluster to run against when no context is provided"),
+		)(tool)
+		mcp.WithNumber(
+			"timeoutSeconds",
+			mcp.Description("Abort the call when it takes longer than this many seconds, defaults to the server timeout"),
+		)(tool)
+	}
+}
+
+// withDryRun adds the dryRun argument accepted by every tool that changes
+// the cluster.
+func withDryRun() mcp.ToolOption {
+	return mcp.WithBoolean(
+		"dryRun",
+		mcp.Description("Run the change on the server with dryRun=All and return the object the API server would persist without saving it"),
+	)
+}
+
+// withPaging adds the limit and continue arguments of the list tools.
+func withPaging() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithNumber(
+			"limit",
+			mcp.Description("Maximum number of items to return, all items when 0 or not set. The result holds a continue token when more items are left"),
+		)(tool)
+		mcp.WithString(
+			"continue",
+			mcp.Description("The continue token returned by the previous call with the same arguments, to get the next page"),
+		)(tool)
+	}
+}
+
+// withListView adds the fieldSelector, sortBy and fields arguments of the
+// list tools, sortKeys are the values sortBy accepts.
+func withListView(sortKeys ...string) mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString(
+			"fieldSelector",
+			mcp.Description("Only return objects matching this field selector. Ex: status.phase=Failed,spec.nodeName=node-3"),
+		)(tool)
+		mcp.WithString(
+			"sortBy",
+			mcp.Description(fmt.Sprintf("Sort the returned items by %s, prefix with - for descending order. With a limit only the items of the page are sorted", strings.Join(sortKeys, ", "))),
+		)(tool)
+		mcp.WithString(
+			"fields",
+			mcp.Description("Comma separated fields to return for each item, all fields when not set. Ex: name,namespace,status"),
+		)(tool)
+	}
+}
+
+// withLogOptions adds the arguments of the log tools that select which
+// lines are returned.
+func withLogOptions() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithNumber(
+			"tailLine",
+			mcp.Description("Number of log line to get from the end of each log, defaults to 100. All lines when 0"),
+		)(tool)
+		mcp.WithBoolean(
+			"previous",
+			mcp.Description("Get the log of the previous terminated container, like the crash of a restarted container"),
+		)(tool)
+		mcp.WithNumber(
+			"sinceSeconds",
+			mcp.Description("Only return lines newer than this many seconds"),
+		)(tool)
+		mcp.WithString(
+			"sinceTime",
+			mcp.Description("Only return lines written after this RFC3339 time. Ex: 2026-10-18T10:00:00Z"),
+		)(tool)
+		mcp.WithBoolean(
+			"timestamps",
+			mcp.Description("Prefix each line with the time it was written"),
+		)(tool)
+		mcp.WithNumber(
+			"limitBytes",
+			mcp.Description("Maximum number of bytes of each log to return"),
+		)(tool)
+	}
+}
+
+// withConfirmation adds the confirmationToken argument of the destructive
+// tools that need a second call to run.
+func withConfirmation() mcp.ToolOption {
+	return mcp.WithString(
+		"confirmationToken",
+		mcp.Description("Token returned by the first call of this tool along with the preview of what is removed. Leave empty to get the preview, pass it back to perform the delete"),
+	)
+}
 
 var ListPodInNamespace = mcp.NewTool(
 	"list-pod-in-namespace",
@@ -16,6 +144,11 @@ var ListPodInNamespace = mcp.NewTool(
 		"label", 
 		mcp.Description("Only return pods matching this label selector"),
 	),
+	withListView("name", "creationTimestamp", "restarts"),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	pod.ListOutput,
 )
 
 var ListPod = mcp.NewTool(
@@ -25,11 +158,16 @@ var ListPod = mcp.NewTool(
 		"label", 
 		mcp.Description("Only return pods matching this label selector"),
 	),
+	withListView("name", "creationTimestamp", "restarts"),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	pod.ListOutput,
 )
 
 var GetPod = mcp.NewTool(
 	"get-pod",
-    mcp.WithDescription("Get the pod in particular namespace with status, label and instance"),
+    mcp.WithDescription("Describe the pod in particular namespace: status, node, IPs, QoS class, conditions, container states with restarts and last termination reason, resource requests and limits, volumes, owners and recent events"),
     mcp.WithString(
 		"namespace",
 		mcp.Required(),
@@ -40,6 +178,9 @@ var GetPod = mcp.NewTool(
 		mcp.Required(),
         mcp.Description("The name of the pod to get details"),
 	),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	pod.GetOutput,
 )
 
 var DeletePod = mcp.NewTool(
@@ -55,6 +196,9 @@ var DeletePod = mcp.NewTool(
 		mcp.Required(),
         mcp.Description("The name of the pod to be deleted"),
 	),
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
 )
 
 var UpdatePod = mcp.NewTool(
@@ -75,6 +219,9 @@ var UpdatePod = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Label to be updated"),
 	),
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
 )
 
 var CreatePod = mcp.NewTool(
@@ -108,6 +255,9 @@ var CreatePod = mcp.NewTool(
 		"containerPorts",
 		mcp.Description("Container port details for the pod"),
 	),
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
 )
 
 var PodLog = mcp.NewTool(
@@ -123,20 +273,215 @@ var PodLog = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Name of the pod to get log"),
 	),
+	mcp.WithString(
+		"containerName",
+		mcp.Description("Container Names for the pod to get log, defaults to the only container of the pod or its kubectl.kubernetes.io/default-container annotation"),
+	),
+	mcp.WithBoolean(
+		"allContainers",
+		mcp.Description("Get the log of every init, regular and ephemeral container interleaved in time order, each line prefixed with [container]"),
+	),
+	mcp.WithBoolean(
+		"follow",
+		mcp.Description("Keep the log open for followSeconds and send each new line to the client as a progress notification, or as a logging notification when the request has no progressToken"),
+	),
 	mcp.WithNumber(
-		"tailLine",
-		mcp.Description("Number of log line to get"),
+		"followSeconds",
+		mcp.Description("How long to follow the log, defaults to 30 seconds and at most 600. The call timeout also ends it, raise timeoutSeconds to follow longer"),
+	),
+	withLogOptions(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	pod.LogOutput,
+)
+
+var WorkloadLogs = mcp.NewTool(
+	"workload-logs",
+	mcp.WithDescription("Get the logs of every pod of a deployment, statefulset, daemonset or job, or of the pods matching a label selector, merged in time order with each line prefixed with [pod/container]"),
+	mcp.WithString(
+		"namespace",
+		mcp.Required(),
+		mcp.Description("The namespace in which the pods are present"),
+	),
+	mcp.WithString(
+		"kind",
+		mcp.Description("Kind of the workload, used with name"),
+		mcp.Enum("deployment", "statefulset", "daemonset", "job"),
+	),
+	mcp.WithString(
+		"name",
+		mcp.Description("Name of the workload whose pods to get the logs of, used with kind"),
+	),
+	mcp.WithString(
+		"labelSelector",
+		mcp.Description("Label selector of the pods to get the logs of, instead of kind and name. Ex: app=web,tier!=cache"),
 	),
 	mcp.WithString(
 		"containerName",
+		mcp.Description("Container to get the log of in each pod, defaults to every container of the pod"),
+	),
+	mcp.WithString(
+		"grep",
+		mcp.Description("Only return the lines matching this regular expression"),
+	),
+	mcp.WithNumber(
+		"maxLines",
+		mcp.Description("Maximum number of merged lines to return, the most recent are kept. Defaults to 1000, all lines when 0"),
+	),
+	withLogOptions(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	pod.WorkloadLogOutput,
+)
+
+var ExecPod = mcp.NewTool(
+	"exec-pod",
+	mcp.WithDescription("Run a non-interactive command in a container of the pod and return its stdout, stderr and exit code. Only the commands in the exec allowlist of the server can run"),
+	mcp.WithString(
+		"namespace",
+		mcp.Required(),
+		mcp.Description("The namespace in which the pod is present"),
+	),
+	mcp.WithString(
+		"name",
 		mcp.Required(),
-		mcp.Description("Container Names for the pod to get log"),
+		mcp.Description("Name of the pod to run the command in"),
+	),
+	mcp.WithArray(
+		"command",
+		mcp.Required(),
+		mcp.WithStringItems(),
+		mcp.Description("The program and its arguments, run without a shell. Ex: [\"ps\", \"aux\"]"),
+	),
+	mcp.WithString(
+		"containerName",
+		mcp.Description("Container to run the command in, defaults to the only container of the pod or its kubectl.kubernetes.io/default-container annotation"),
+	),
+	mcp.WithNumber(
+		"maxOutputBytes",
+		mcp.Description("Maximum number of bytes of stdout and of stderr to return, defaults to 65536 and at most 1048576"),
 	),
+	withCluster(),
+	pod.ExecOutput,
+)
+
+var CopyFromPod = mcp.NewTool(
+	"copy-from-pod",
+	mcp.WithDescription("Read a file or directory out of a container of the pod, like kubectl cp. Only the paths in the copy read list of the server can be read"),
+	mcp.WithString(
+		"namespace",
+		mcp.Required(),
+		mcp.Description("The namespace in which the pod is present"),
+	),
+	mcp.WithString(
+		"name",
+		mcp.Required(),
+		mcp.Description("Name of the pod to copy from"),
+	),
+	mcp.WithString(
+		"path",
+		mcp.Required(),
+		mcp.Description("Absolute path of the file or directory in the container. Ex: /etc/nginx/nginx.conf"),
+	),
+	mcp.WithString(
+		"containerName",
+		mcp.Description("Container to copy from, defaults to the only container of the pod or its kubectl.kubernetes.io/default-container annotation"),
+	),
+	mcp.WithString(
+		"encoding",
+		mcp.Description("Encoding of the returned content. Defaults to text for UTF-8 files and base64 for binary files, base64 returns every file as base64"),
+		mcp.Enum("text", "base64"),
+	),
+	mcp.WithNumber(
+		"maxBytes",
+		mcp.Description("Maximum number of bytes of file content to return, defaults to 1048576 and at most 10485760"),
+	),
+	withCluster(),
+	pod.CopyFromOutput,
+)
+
+var CopyToPod = mcp.NewTool(
+	"copy-to-pod",
+	mcp.WithDescription("Write a file into a container of the pod, like kubectl cp. Only the paths in the copy write list of the server can be written"),
+	mcp.WithString(
+		"namespace",
+		mcp.Required(),
+		mcp.Description("The namespace in which the pod is present"),
+	),
+	mcp.WithString(
+		"name",
+		mcp.Required(),
+		mcp.Description("Name of the pod to copy to"),
+	),
+	mcp.WithString(
+		"path",
+		mcp.Required(),
+		mcp.Description("Absolute path of the file to write in the container, its directory must exist. Ex: /tmp/debug.conf"),
+	),
+	mcp.WithString(
+		"content",
+		mcp.Required(),
+		mcp.Description("Content of the file, at most 10485760 bytes"),
+	),
+	mcp.WithString(
+		"encoding",
+		mcp.Description("Encoding of content, defaults to text. Use base64 for binary files"),
+		mcp.Enum("text", "base64"),
+	),
+	mcp.WithString(
+		"containerName",
+		mcp.Description("Container to copy to, defaults to the only container of the pod or its kubectl.kubernetes.io/default-container annotation"),
+	),
+	withCluster(),
+	result.ChangeOutput,
+)
+
+var DebugPod = mcp.NewTool(
+	"debug-pod",
+	mcp.WithDescription("Add an ephemeral debug container with a debug image to a running pod, like kubectl debug, for images without a shell. Without a command the container stays up for exec-pod, with a command its output is returned once it exits. Only the images in the debug list and the commands in the exec allowlist of the server can run. Ephemeral containers cannot be removed from the pod"),
+	mcp.WithString(
+		"namespace",
+		mcp.Required(),
+		mcp.Description("The namespace in which the pod is present"),
+	),
+	mcp.WithString(
+		"name",
+		mcp.Required(),
+		mcp.Description("Name of the pod to debug"),
+	),
+	mcp.WithString(
+		"image",
+		mcp.Required(),
+		mcp.Description("Image of the debug container. Ex: busybox:1.36"),
+	),
+	mcp.WithString(
+		"targetContainer",
+		mcp.Description("Container whose process namespace the debug container joins, defaults to the only container of the pod or its kubectl.kubernetes.io/default-container annotation"),
+	),
+	mcp.WithString(
+		"debugContainer",
+		mcp.Description("Name of the debug container, defaults to a generated name like debugger-x7k2q"),
+	),
+	mcp.WithArray(
+		"command",
+		mcp.WithStringItems(),
+		mcp.Description("The program and its arguments the debug container runs instead of the image entrypoint. Ex: [\"ps\", \"aux\"]"),
+	),
+	mcp.WithNumber(
+		"maxOutputBytes",
+		mcp.Description("Maximum number of bytes of the command output to return, defaults to 65536 and at most 1048576"),
+	),
+	withCluster(),
+	pod.DebugOutput,
 )
 
 var ListNS = mcp.NewTool( 
 	"list-ns",
 	mcp.WithDescription("List the namespace in the kubernetes cluster with status"),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	namespace.ListOutput,
 )
 
 var GetNS = mcp.NewTool( 
@@ -147,6 +492,9 @@ var GetNS = mcp.NewTool(
 		mcp.Required(),
         mcp.Description("The name of the namespace to get details for"),
 	),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	namespace.GetOutput,
 )
 
 var DeleteNS = mcp.NewTool( 
@@ -157,6 +505,10 @@ var DeleteNS = mcp.NewTool(
 		mcp.Required(),
         mcp.Description("The name of the namespace to be deleted"),
 	),
+	withCluster(),
+	withDryRun(),
+	withConfirmation(),
+	confirm.DeleteOutput,
 )
 
 var UpdateNS = mcp.NewTool(
@@ -175,6 +527,9 @@ var UpdateNS = mcp.NewTool(
 		"annotation",
 		mcp.Description("annotation to be updated"),
 	),
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
 )
 
 var CreateNS = mcp.NewTool(
@@ -189,6 +544,9 @@ var CreateNS = mcp.NewTool(
 		"label",
 		mcp.Description("Label to be add in hte namespace"),
 	),
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
 )
 
 var ListDeploymentInNamespace = mcp.NewTool(
@@ -203,6 +561,11 @@ var ListDeploymentInNamespace = mcp.NewTool(
 		"label", 
 		mcp.Description("The deployment should be listed only if this particular label is exist"),
 	),
+	withListView("name", "creationTimestamp"),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	deployment.ListOutput,
 )
 
 var ListDeployment = mcp.NewTool(
@@ -212,6 +575,11 @@ var ListDeployment = mcp.NewTool(
 		"label", 
 		mcp.Description("The deployment should be listed only if this particular label is exist"),
 	),
+	withListView("name", "creationTimestamp"),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	deployment.ListOutput,
 )
 
 var GetDeployment = mcp.NewTool(
@@ -227,6 +595,9 @@ var GetDeployment = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Name of the deployment to get"),
 	),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	deployment.GetOutput,
 )
 
 var DeleteDeployment = mcp.NewTool(
@@ -242,6 +613,10 @@ var DeleteDeployment = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Name of the deployment to be deleted"),
 	),
+	withCluster(),
+	withDryRun(),
+	withConfirmation(),
+	confirm.DeleteOutput,
 )
 
 var UpdateDeployment = mcp.NewTool(
@@ -277,7 +652,9 @@ var UpdateDeployment = mcp.NewTool(
 		"image",
 		mcp.Description("Image to be updated"),
 	),
-
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
 )
 
 var CreateDeployment = mcp.NewTool(
@@ -315,7 +692,9 @@ var CreateDeployment = mcp.NewTool(
 		"containerPorts",
 		mcp.Description("Container port details for the deployment"),
 	),
-
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
 )
 
 var ListServiceInNamespace = mcp.NewTool(
@@ -326,11 +705,21 @@ var ListServiceInNamespace = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("The namespace in which the service should be listed"),
 	),
+	withListView("name", "creationTimestamp"),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	service.ListOutput,
 )
 
 var ListService = mcp.NewTool(
 	"list-service",
 	mcp.WithDescription("List the service in the all namespace with type"),
+	withListView("name", "creationTimestamp"),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	service.ListOutput,
 )
 
 var GetService = mcp.NewTool(
@@ -346,6 +735,9 @@ var GetService = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Name of the service to get"),
 	),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	service.GetOutput,
 )
 
 var DeleteService = mcp.NewTool(
@@ -361,6 +753,9 @@ var DeleteService = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Name of the service to be deleted"),
 	),
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
 )
 
 var UpdateService = mcp.NewTool(
@@ -384,6 +779,9 @@ var UpdateService = mcp.NewTool(
 		"svctype",
 		mcp.Description("Service type to be updated"),
 	),
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
 )
 
 var CreateService = mcp.NewTool(
@@ -418,6 +816,9 @@ var CreateService = mcp.NewTool(
 		"svcType",
 		mcp.Description("Service type need to create, if not provided it will take default service type"),
 	),
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
 )
 
 var  ListStatefulsetInNamespace = mcp.NewTool(
@@ -432,6 +833,10 @@ var  ListStatefulsetInNamespace = mcp.NewTool(
 		"label", 
 		mcp.Description("Get the statefulset only if this particular label is exist"),
 	),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	statefulset.ListOutput,
 )
 
 var ListStatefulset = mcp.NewTool(
@@ -441,6 +846,10 @@ var ListStatefulset = mcp.NewTool(
 		"label", 
 		mcp.Description("Get the statefulset only if this particular label is exist"),
 	),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	statefulset.ListOutput,
 )
 
 var  GetStatefulset = mcp.NewTool(
@@ -456,6 +865,9 @@ var  GetStatefulset = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Name of the statefulset to get"),
 	),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	statefulset.GetOutput,
 )
 
 var  DeleteStatefulset = mcp.NewTool(
@@ -471,6 +883,9 @@ var  DeleteStatefulset = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Name of the statefulset to be deleted"),
 	),
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
 )
 
 var UpdateStatefulset = mcp.NewTool(
@@ -506,6 +921,9 @@ var UpdateStatefulset = mcp.NewTool(
 		"image",
 		mcp.Description("Image to be updated"),
 	),
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
 )
 
 var CreateStatefulset = mcp.NewTool(
@@ -564,6 +982,9 @@ var CreateStatefulset = mcp.NewTool(
 		"replica",
 		mcp.Description("Number of replica for statefulset"),
 	),
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
 )
 
 var ListDaemonsetInNamespace = mcp.NewTool(
@@ -578,6 +999,10 @@ var ListDaemonsetInNamespace = mcp.NewTool(
 		"label", 
 		mcp.Description("The daemonset should be listed only if this particular label is exist"),
 	),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	daemonset.ListOutput,
 )
 
 var ListDaemonset = mcp.NewTool(
@@ -587,6 +1012,10 @@ var ListDaemonset = mcp.NewTool(
 		"label", 
 		mcp.Description("Get the daemonset only if this particular label is exist"),
 	),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	daemonset.ListOutput,
 )
 
 var GetDaemonset = mcp.NewTool(
@@ -602,6 +1031,9 @@ var GetDaemonset = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Name of the daemonset to get"),
 	),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	daemonset.GetOutput,
 )
 
 var DeleteDaemonset = mcp.NewTool(
@@ -617,6 +1049,9 @@ var DeleteDaemonset = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Name of the daemonset to be deleted"),
 	),
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
 )
 
 var UpdateDaemonset = mcp.NewTool(
@@ -648,7 +1083,9 @@ var UpdateDaemonset = mcp.NewTool(
 		"image",
 		mcp.Description("Image to be updated"),
 	),
-
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
 )
 
 var CreateDaemonset = mcp.NewTool(
@@ -682,7 +1119,9 @@ var CreateDaemonset = mcp.NewTool(
 		"containerPorts",
 		mcp.Description("Container port details for the daemonset"),
 	),
-
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
 )
 
 var ListConfigmapInNamespace = mcp.NewTool(
@@ -693,11 +1132,19 @@ var ListConfigmapInNamespace = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("The namespace in which the configmap should be listed"),
 	),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	configmap.ListOutput,
 )
 
 var ListConfigmap = mcp.NewTool(
 	"list-configmap",
 	mcp.WithDescription("List the configmap in the all namespace"),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	configmap.ListOutput,
 )
 
 var GetConfigmap = mcp.NewTool(
@@ -713,6 +1160,9 @@ var GetConfigmap = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Name of the configmap to get"),
 	),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	configmap.GetOutput,
 )
 
 var DeleteConfigmap = mcp.NewTool(
@@ -728,6 +1178,9 @@ var DeleteConfigmap = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Name of the configmap to be deleted"),
 	),
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
 )
 
 var CreateConfigmap = mcp.NewTool(
@@ -748,6 +1201,9 @@ var CreateConfigmap = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Data of the configmap to be created for"),
 	),
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
 )
 
 
@@ -759,11 +1215,19 @@ var ListSecretInNamespace = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("The namespace in which the secret should be listed"),
 	),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	secret.ListOutput,
 )
 
 var ListSecret = mcp.NewTool(
 	"list-secret",
 	mcp.WithDescription("List the secret in the all namespace"),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	secret.ListOutput,
 )
 
 var GetSecret = mcp.NewTool(
@@ -779,6 +1243,9 @@ var GetSecret = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Name of the secret to get"),
 	),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	secret.GetOutput,
 )
 
 var DeleteSecret = mcp.NewTool(
@@ -794,6 +1261,9 @@ var DeleteSecret = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Name of the secret to be deleted"),
 	),
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
 )
 
 var CreateSecret = mcp.NewTool(
@@ -814,11 +1284,19 @@ var CreateSecret = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Data of the secret to be created for"),
 	),
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
 )
 	
 var ListNode = mcp.NewTool(
 	"list-node",
 	mcp.WithDescription("List the node in the kubernetes cluster with status"),
+	withListView("name", "creationTimestamp"),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	node.ListOutput,
 )
 
 var GetNode = mcp.NewTool(
@@ -829,6 +1307,9 @@ var GetNode = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Name of the node to get"),
 	),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	node.GetOutput,
 )
 
 var DeleteNode = mcp.NewTool(
@@ -839,6 +1320,10 @@ var DeleteNode = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Name of the node to be deleted"),
 	),
+	withCluster(),
+	withDryRun(),
+	withConfirmation(),
+	confirm.DeleteOutput,
 )
 
 var UpdateNode = mcp.NewTool(
@@ -854,6 +1339,9 @@ var UpdateNode = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Label to be updated"),
 	),
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
 )
 
 var ListSA = mcp.NewTool(
@@ -863,6 +1351,10 @@ var ListSA = mcp.NewTool(
 		"label",
 		mcp.Description("Label of the serviceAccount, if we need to list the service account with particualr label exist"),
 	),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	serviceaccount.ListOutput,
 )
 
 var ListSAInNS = mcp.NewTool(
@@ -877,6 +1369,10 @@ var ListSAInNS = mcp.NewTool(
 		"label",
 		mcp.Description("Label of the serviceAccount, if we need to list the service account with particualr label"),
 	),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	serviceaccount.ListOutput,
 )
 
 var GetSA = mcp.NewTool(
@@ -892,6 +1388,9 @@ var GetSA = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Name of the serviceAccount to get"),
 	),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	serviceaccount.GetOutput,
 )
 
 var DeleteSA = mcp.NewTool(
@@ -907,6 +1406,9 @@ var DeleteSA = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Name of the serviceAccount to delete"),
 	),
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
 )
 
 var CreateSA = mcp.NewTool(
@@ -926,6 +1428,9 @@ var CreateSA = mcp.NewTool(
 		"label",
 		mcp.Description("Label of the serviceAccount, if we need to create the service account with particualr label"),
 	),
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
 )
 
 var ListPVCInNS = mcp.NewTool(
@@ -936,11 +1441,21 @@ var ListPVCInNS = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Namespace of the pvc to be listed"),
 	),
+	withListView("name", "creationTimestamp"),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	pvc.ListOutput,
 )
 
 var ListPVC = mcp.NewTool(
 	"list-pvc",
 	mcp.WithDescription("List the pvc in all namespace"),
+	withListView("name", "creationTimestamp"),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	pvc.ListOutput,
 )
 
 var GetPVC = mcp.NewTool(
@@ -956,6 +1471,9 @@ var GetPVC = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Name of the pvc to get"),
 	),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	pvc.GetOutput,
 )
 
 var DeletePVC = mcp.NewTool(
@@ -971,6 +1489,9 @@ var DeletePVC = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Name of the pvc to delete"),
 	),
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
 )
 
 var UpdatePVC = mcp.NewTool(
@@ -991,6 +1512,9 @@ var UpdatePVC = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("size of the pvc to update"),
 	),
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
 )
 
 var CreatePVC = mcp.NewTool(
@@ -1020,11 +1544,18 @@ var CreatePVC = mcp.NewTool(
 		"accessMode",
 		mcp.Description("AccessModes of the pvc to create"),
 	),
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
 )
 
 var ListPV = mcp.NewTool(
 	"list-pv",
 	mcp.WithDescription("List the entire pv"),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	pv.ListOutput,
 )
 
 var GetPV = mcp.NewTool(
@@ -1035,6 +1566,9 @@ var GetPV = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Name of the pv to get"),
 	),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	pv.GetOutput,
 )
 
 var DeletePV = mcp.NewTool(
@@ -1045,6 +1579,10 @@ var DeletePV = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Name of the pv to delete"),
 	),
+	withCluster(),
+	withDryRun(),
+	withConfirmation(),
+	confirm.DeleteOutput,
 )
 
 
@@ -1056,11 +1594,19 @@ var ListRoleInNS = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Namespace of the role to list"),
 	),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	role.ListOutput,
 )
 
 var ListRole = mcp.NewTool(
 	"list-role",
 	mcp.WithDescription("List the role in all namespace"),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	role.ListOutput,
 )
 
 var GetRole = mcp.NewTool(
@@ -1076,6 +1622,9 @@ var GetRole = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Name of the role to get"),
 	),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	role.GetOutput,
 )
 
 var ListRBInNS = mcp.NewTool(
@@ -1086,11 +1635,19 @@ var ListRBInNS = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Namespace of the rolebinding to list"),
 	),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	rolebinding.ListOutput,
 )
 
 var ListRB = mcp.NewTool(
 	"list-rolebinding",
 	mcp.WithDescription("List the rolebinding in all namespace"),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	rolebinding.ListOutput,
 )
 
 var GetRB = mcp.NewTool(
@@ -1106,12 +1663,19 @@ var GetRB = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Name of the rolebinding to get"),
 	),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	rolebinding.GetOutput,
 )
 
 
 var ListCR = mcp.NewTool(
 	"list-clusterrole",
 	mcp.WithDescription("List all the clusterrole in the cluster"),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	clusterrole.ListOutput,
 )
 
 var GetCR = mcp.NewTool(
@@ -1122,11 +1686,18 @@ var GetCR = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Name of the clusterrole to get"),
 	),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	clusterrole.GetOutput,
 )
 
 var ListCRB = mcp.NewTool(
 	"list-clusterrolebinding",
 	mcp.WithDescription("List all the clusterrolebinding in the cluster"),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	clusterrolebinding.ListOutput,
 )
 
 var GetCRB = mcp.NewTool(
@@ -1137,11 +1708,18 @@ var GetCRB = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Name of the clusterrolebinding to get"),
 	),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	clusterrolebinding.GetOutput,
 )
 
 var ListSC = mcp.NewTool(
 	"list-storageClass",
 	mcp.WithDescription("List the storageClass in the entier cluster"),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	storageclass.ListOutput,
 )
 
 var GetSC = mcp.NewTool(
@@ -1152,4 +1730,217 @@ var GetSC = mcp.NewTool(
 		mcp.Required(),
 		mcp.Description("Name of the storageClass to get"),
 	),
-)
\ No newline at end of file
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	storageclass.GetOutput,
+)
+
+var ListCluster = mcp.NewTool(
+	"list-cluster",
+	mcp.WithDescription("List the kubeconfig contexts with their cluster, server and user that other tools can target with the context or cluster argument"),
+	mcp.WithReadOnlyHintAnnotation(true),
+	cluster.ListOutput,
+)
+
+var ApplyManifest = mcp.NewTool(
+	"apply-manifest",
+	mcp.WithDescription("Apply one or more YAML or JSON manifests with server-side apply and return whether each object was created, configured or unchanged"),
+	mcp.WithString(
+		"manifest",
+		mcp.Required(),
+		mcp.Description("The manifest to apply, multiple YAML documents are separated by ---. Any kind served by the cluster is accepted, including custom resources"),
+	),
+	mcp.WithString(
+		"namespace",
+		mcp.Description("Namespace for namespaced objects that do not set one, defaults to default"),
+	),
+	mcp.WithString(
+		"fieldManager",
+		mcp.Description("Field manager name recorded for the applied fields, defaults to k8s-mcp-server"),
+	),
+	mcp.WithBoolean(
+		"force",
+		mcp.Description("Take ownership of fields owned by other field managers instead of failing with a conflict"),
+	),
+	withCluster(),
+	withDryRun(),
+	manifest.ApplyOutput,
+)
+
+// withResource adds the arguments used to address any kind served by the
+// cluster.
+func withResource() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString(
+			"resource",
+			mcp.Required(),
+			mcp.Description("Kind, plural, singular or short name of the resource, optionally with its group. Ex: deploy, certificates.cert-manager.io, ServiceMonitor"),
+		)(tool)
+		mcp.WithString(
+			"apiVersion",
+			mcp.Description("Group and version to use when the resource is served by several versions. Ex: cert-manager.io/v1"),
+		)(tool)
+	}
+}
+
+var ListResource = mcp.NewTool(
+	"list-resource",
+	mcp.WithDescription("List objects of any kind served by the cluster, including custom resources, with name, namespace, labels and creation time"),
+	withResource(),
+	mcp.WithString(
+		"namespace",
+		mcp.Description("Namespace to list in, all namespaces when empty. Ignored for cluster scoped resources"),
+	),
+	mcp.WithString(
+		"label",
+		mcp.Description("Only return objects matching this label selector"),
+	),
+	mcp.WithString(
+		"fieldSelector",
+		mcp.Description("Only return objects matching this field selector. Ex: metadata.name=web"),
+	),
+	withPaging(),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	resource.ListOutput,
+)
+
+var GetResource = mcp.NewTool(
+	"get-resource",
+	mcp.WithDescription("Get the full object of any kind served by the cluster, including custom resources"),
+	withResource(),
+	mcp.WithString(
+		"namespace",
+		mcp.Description("Namespace of the object, required for namespaced resources"),
+	),
+	mcp.WithString(
+		"name",
+		mcp.Required(),
+		mcp.Description("Name of the object to get"),
+	),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	resource.GetOutput,
+)
+
+var DeleteResource = mcp.NewTool(
+	"delete-resource",
+	mcp.WithDescription("Delete an object of any kind served by the cluster, including custom resources. Namespaces, nodes, pvs and deployments must be deleted with their own tools"),
+	withResource(),
+	mcp.WithString(
+		"namespace",
+		mcp.Description("Namespace of the object, required for namespaced resources"),
+	),
+	mcp.WithString(
+		"name",
+		mcp.Required(),
+		mcp.Description("Name of the object to delete"),
+	),
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
+)
+
+var PatchResource = mcp.NewTool(
+	"patch-resource",
+	mcp.WithDescription("Patch an object of any kind served by the cluster, including custom resources"),
+	withResource(),
+	mcp.WithString(
+		"namespace",
+		mcp.Description("Namespace of the object, required for namespaced resources"),
+	),
+	mcp.WithString(
+		"name",
+		mcp.Required(),
+		mcp.Description("Name of the object to patch"),
+	),
+	mcp.WithString(
+		"patch",
+		mcp.Required(),
+		mcp.Description("The patch document in JSON. Ex: {\"spec\":{\"replicas\":3}}"),
+	),
+	mcp.WithString(
+		"patchType",
+		mcp.Description("Type of the patch, defaults to merge. Strategic merge only works for built-in kinds"),
+		mcp.Enum("merge", "json", "strategic"),
+	),
+	withCluster(),
+	withDryRun(),
+	result.ChangeOutput,
+)
+
+var APIResources = mcp.NewTool(
+	"api-resources",
+	mcp.WithDescription("List the resources served by the cluster with their kind, api version, short names, namespaced flag and supported verbs, including custom resources"),
+	mcp.WithString(
+		"apiGroup",
+		mcp.Description("Only return resources of this api group. Use core or an empty string for the core group"),
+	),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	apiresource.ListOutput,
+)
+
+var Explain = mcp.NewTool(
+	"explain",
+	mcp.WithDescription("Describe a resource or one of its fields from the OpenAPI schema of the cluster, with its type, description and sub fields"),
+	mcp.WithString(
+		"field",
+		mcp.Required(),
+		mcp.Description("Resource optionally followed by a field path. Ex: deployment, deployment.spec.strategy, pod.spec.containers.resources"),
+	),
+	mcp.WithString(
+		"apiVersion",
+		mcp.Description("Group and version to explain when the resource is served by several versions. Ex: apps/v1"),
+	),
+	withCluster(),
+	mcp.WithReadOnlyHintAnnotation(true),
+	apiresource.ExplainOutput,
+)
+
+var StartPortForward = mcp.NewTool(
+	"start-port-forward",
+	mcp.WithDescription("Forward a local port of the server on 127.0.0.1 to a port of a pod, or of a running and ready pod backing a service, so the endpoint can be probed locally. The session stays open until stop-port-forward is called"),
+	mcp.WithString(
+		"namespace",
+		mcp.Required(),
+		mcp.Description("The namespace in which the pod or service is present"),
+	),
+	mcp.WithString(
+		"pod",
+		mcp.Description("Name of the pod to forward to, instead of service"),
+	),
+	mcp.WithString(
+		"service",
+		mcp.Description("Name of the service to forward to, instead of pod. One of its running and ready pods is picked"),
+	),
+	mcp.WithString(
+		"port",
+		mcp.Required(),
+		mcp.Description("Number or name of the container port of the pod, or of the service port. Ex: 8080 or http"),
+	),
+	mcp.WithNumber(
+		"localPort",
+		mcp.Description("Local port to listen on, defaults to a random free port"),
+	),
+	withCluster(),
+	forward.SessionOutput,
+)
+
+var ListPortForwards = mcp.NewTool(
+	"list-port-forwards",
+	mcp.WithDescription("List the port-forward sessions started by the caller, with their local address and whether they are still active"),
+	mcp.WithReadOnlyHintAnnotation(true),
+	forward.ListOutput,
+)
+
+var StopPortForward = mcp.NewTool(
+	"stop-port-forward",
+	mcp.WithDescription("Stop a port-forward session and release its local port"),
+	mcp.WithString(
+		"id",
+		mcp.Required(),
+		mcp.Description("The id of the session returned by start-port-forward. Ex: pf-1"),
+	),
+	result.ChangeOutput,
+)
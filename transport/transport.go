@@ -0,0 +1,153 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/naveenthangaraj03/k8s-mcp-server/auth"
+)
+
+const (
+	Stdio = "stdio"
+	HTTP  = "http"
+	SSE   = "sse"
+)
+
+// Endpoints the HTTP transports are served on.
+const (
+	httpEndpoint    = "/mcp"
+	sseEndpoint     = "/sse"
+	messageEndpoint = "/message"
+)
+
+var transport string
+var listen string
+var tlsCert string
+var tlsKey string
+var shutdownTimeout time.Duration
+
+func init() {
+	flag.StringVar(&transport, "transport", Stdio, "Transport to serve MCP on: stdio, http (streamable HTTP on /mcp) or sse (SSE on /sse and /message)")
+	flag.StringVar(&listen, "listen", "localhost:8080", "Address the http and sse transports listen on, use :8080 to listen on every interface")
+	flag.StringVar(&tlsCert, "tlsCert", "", "Certificate file to serve the http and sse transports over TLS, requires --tlsKey")
+	flag.StringVar(&tlsKey, "tlsKey", "", "Private key file of --tlsCert")
+	flag.DurationVar(&shutdownTimeout, "shutdownTimeout", 10*time.Second, "How long the http and sse transports wait for open requests to finish on SIGINT or SIGTERM")
+}
+
+// Name returns the transport chosen with --transport.
+func Name() string {
+	return transport
+}
+
+// shutdowner is the part of the mcp-go HTTP servers used to stop them.
+type shutdowner interface {
+	Shutdown(ctx context.Context) error
+}
+
+// Serve runs s on the transport chosen with --transport. It returns when the
+// client disconnects from stdio, or once the HTTP server is shut down after
+// SIGINT or SIGTERM.
+func Serve(s *server.MCPServer) error {
+	if (tlsCert == "") != (tlsKey == "") {
+		return fmt.Errorf("--tlsCert and --tlsKey must be set together")
+	}
+	switch transport {
+	case Stdio:
+		if tlsCert != "" {
+			return fmt.Errorf("--tlsCert and --tlsKey only apply to the http and sse transports")
+		}
+		if auth.Enabled() {
+			return fmt.Errorf("authentication only applies to the http and sse transports")
+		}
+		return server.ServeStdio(s)
+	case HTTP, SSE:
+		return serveHTTP(s)
+	}
+	return fmt.Errorf("unknown transport %q, use stdio, http or sse", transport)
+}
+
+func serveHTTP(s *server.MCPServer) error {
+	authenticator, err := auth.New(context.Background())
+	if err != nil {
+		return err
+	}
+	if authenticator == nil {
+		log.Printf("transport: no authentication is configured, every caller acts with the server credentials")
+	}
+	httpServer := &http.Server{
+		Addr:              listen,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	var mcpServer shutdowner
+	var endpoint string
+	switch transport {
+	case HTTP:
+		streamable := server.NewStreamableHTTPServer(s,
+			server.WithEndpointPath(httpEndpoint),
+			server.WithStreamableHTTPServer(httpServer),
+		)
+		mux := http.NewServeMux()
+		mux.Handle(httpEndpoint, streamable)
+		httpServer.Handler = mux
+		mcpServer = streamable
+		endpoint = httpEndpoint
+	case SSE:
+		sse := server.NewSSEServer(s,
+			server.WithSSEEndpoint(sseEndpoint),
+			server.WithMessageEndpoint(messageEndpoint),
+			server.WithHTTPServer(httpServer),
+		)
+		httpServer.Handler = sse
+		mcpServer = sse
+		endpoint = sseEndpoint
+	}
+
+	if authenticator != nil {
+		httpServer.Handler = authenticator.Middleware(httpServer.Handler)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	scheme := "http"
+	if tlsCert != "" {
+		scheme = "https"
+	}
+	log.Printf("transport: serving %s on %s://%s%s", transport, scheme, listen, endpoint)
+	serveErr := make(chan error, 1)
+	go func() {
+		if tlsCert != "" {
+			serveErr <- httpServer.ListenAndServeTLS(tlsCert, tlsKey)
+			return
+		}
+		serveErr <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+	log.Printf("transport: shutting down, waiting up to %s for open requests", shutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := mcpServer.Shutdown(shutdownCtx); err != nil {
+		// Streams that are still open after the timeout are cut.
+		httpServer.Close()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			return err
+		}
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}